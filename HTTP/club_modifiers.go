@@ -0,0 +1,86 @@
+package HTTP
+
+import (
+	"Fairway_Bridge/Shared"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// clubParam returns the club named in the request path, or writes an error when it is not a known club.
+func clubParam(c *gin.Context) (Shared.ClubType, bool) {
+	club := Shared.ClubType(c.Param("club"))
+	if !Shared.ValidClubTypes[club] {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unknown club type %s", club)})
+		return "", false
+	}
+	return club, true
+}
+
+// getAllClubModifiers handles GET /modifiers/clubs
+func getAllClubModifiers(units Shared.UnitSystem) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		profiles := Shared.GetAllClubModifiers()
+		for club, md := range profiles {
+			profiles[club] = Shared.ConvertModifierUnits(md, Shared.StandardUnits, units)
+		}
+		c.JSON(http.StatusOK, profiles)
+	}
+}
+
+// getClubModifiers handles GET /modifiers/clubs/:club
+func getClubModifiers(units Shared.UnitSystem) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		club, ok := clubParam(c)
+		if !ok {
+			return
+		}
+		md, ok := Shared.GetClubModifiers(club)
+		if !ok {
+			c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("%s has no modifier profile", club)})
+			return
+		}
+		c.JSON(http.StatusOK, Shared.ConvertModifierUnits(md, Shared.StandardUnits, units))
+	}
+}
+
+// updateClubModifiers handles PUT /modifiers/clubs/:club
+func updateClubModifiers(units Shared.UnitSystem) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		club, ok := clubParam(c)
+		if !ok {
+			return
+		}
+		// The body is decoded over the club's current modifiers, or the default modifiers for a new profile,
+		// so fields it leaves out keep their value.
+		current, ok := Shared.GetClubModifiers(club)
+		if !ok {
+			current = Shared.GetModifiers()
+		}
+		newModifiers := Shared.ConvertModifierUnits(current, Shared.StandardUnits, units)
+		if err := c.ShouldBindJSON(&newModifiers); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON"})
+			return
+		}
+		if err := newModifiers.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		Shared.SetClubModifiers(club, Shared.ConvertModifierUnits(newModifiers, units, Shared.StandardUnits))
+		c.Status(http.StatusOK)
+	}
+}
+
+// deleteClubModifiers handles DELETE /modifiers/clubs/:club
+func deleteClubModifiers(c *gin.Context) {
+	club, ok := clubParam(c)
+	if !ok {
+		return
+	}
+	if !Shared.DeleteClubModifiers(club) {
+		c.JSON(http.StatusNotFound, gin.H{"error": fmt.Sprintf("%s has no modifier profile", club)})
+		return
+	}
+	c.Status(http.StatusOK)
+}
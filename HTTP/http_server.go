@@ -2,7 +2,11 @@ package HTTP
 
 import (
 	"Fairway_Bridge/Cameras"
+	"Fairway_Bridge/Launch_Monitors"
+	"Fairway_Bridge/Router"
+	"Fairway_Bridge/Rules"
 	"Fairway_Bridge/Shared"
+	"Fairway_Bridge/Simulators"
 	"bufio"
 	"fmt"
 	"github.com/gin-gonic/gin"
@@ -25,24 +29,33 @@ const (
 )
 
 // getModifiers handles GET /modifiers
-func getModifiers(c *gin.Context) {
-	dataMutex.RLock()
-	defer dataMutex.RUnlock()
-	c.JSON(http.StatusOK, Shared.GetModifiers())
+func getModifiers(units Shared.UnitSystem) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		dataMutex.RLock()
+		defer dataMutex.RUnlock()
+		c.JSON(http.StatusOK, Shared.ConvertModifierUnits(Shared.GetModifiers(), Shared.StandardUnits, units))
+	}
 }
 
 // updateModifiers handles PUT /modifiers
-func updateModifiers(c *gin.Context) {
-	var newModifiers Shared.ModifierData
-	if err := c.ShouldBindJSON(&newModifiers); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON"})
-		return
-	}
+func updateModifiers(units Shared.UnitSystem) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		// The body is decoded over the current modifiers so fields it leaves out keep their value.
+		newModifiers := Shared.ConvertModifierUnits(Shared.GetModifiers(), Shared.StandardUnits, units)
+		if err := c.ShouldBindJSON(&newModifiers); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON"})
+			return
+		}
+		if err := newModifiers.Validate(); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 
-	dataMutex.Lock()
-	defer dataMutex.Unlock()
-	Shared.SetModifiers(newModifiers)
-	c.Status(http.StatusOK)
+		dataMutex.Lock()
+		defer dataMutex.Unlock()
+		Shared.SetModifiers(Shared.ConvertModifierUnits(newModifiers, units, Shared.StandardUnits))
+		c.Status(http.StatusOK)
+	}
 }
 
 // saveModifiers handles POST /modifiers/save
@@ -181,8 +194,66 @@ func deleteCamera(cam Cameras.CameraController) gin.HandlerFunc {
 	}
 }
 
+// nextReplayShot handles POST /replay/next
+func nextReplayShot(lm Launch_Monitors.LaunchMonitorController) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		stepper, ok := Launch_Monitors.Find[Launch_Monitors.Stepper](lm)
+		if !ok {
+			c.JSON(http.StatusConflict, gin.H{"error": "Launch monitor does not support stepping"})
+			return
+		}
+
+		if err := stepper.Step(); err != nil {
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.Status(http.StatusOK)
+	}
+}
+
+// getReplayStatus handles GET /replay/status
+func getReplayStatus(lm Launch_Monitors.LaunchMonitorController) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		stepper, ok := Launch_Monitors.Find[Launch_Monitors.Stepper](lm)
+		if !ok {
+			c.JSON(http.StatusConflict, gin.H{"error": "Launch monitor does not support stepping"})
+			return
+		}
+
+		position, total := stepper.Progress()
+		c.JSON(http.StatusOK, gin.H{"Position": position, "Total": total})
+	}
+}
+
+// getSimulatorStatus returns the simulator's connection state and how many shots are queued for it
+func getSimulatorStatus(sim Simulators.SimulatorController) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		reporter, ok := sim.(Simulators.ConnectionReporter)
+		if !ok {
+			c.JSON(http.StatusConflict, gin.H{"error": "Simulator does not report its connection"})
+			return
+		}
+
+		c.JSON(http.StatusOK, reporter.ConnectionStatus())
+	}
+}
+
+// getRecentShots returns the most recent shots, newest first, with whether each reached the simulator
+func getRecentShots(units Shared.UnitSystem) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		shots := Shared.GetRecentShots()
+		for i := range shots {
+			shots[i].Ball = Shared.ConvertBallUnits(shots[i].Ball, Shared.StandardUnits, units)
+			shots[i].Club = Shared.ConvertClubUnits(shots[i].Club, Shared.StandardUnits, units)
+			shots[i].Result = resultIn(shots[i].Result, units)
+		}
+		c.JSON(http.StatusOK, shots)
+	}
+}
+
 // Serve starts the HTTP server with the given logger, log buffer, IP address, and port
-func Serve(logger *zap.Logger, config Shared.Config, cam Cameras.CameraController) error {
+func Serve(logger *zap.Logger, config Shared.Config, cam Cameras.CameraController, lm Launch_Monitors.LaunchMonitorController, sim Simulators.SimulatorController, rules *Rules.Engine, quarantine *Router.Quarantine) error {
 	log := logger.With(zap.String("component", "HTTP")).Sugar()
 
 	// Ensure upload directory exists
@@ -200,9 +271,15 @@ func Serve(logger *zap.Logger, config Shared.Config, cam Cameras.CameraControlle
 	r.Static("assets", "Assets")
 
 	// API Endpoints
-	r.GET("/modifiers", getModifiers)
-	r.PUT("/modifiers", updateModifiers)
+	r.GET("/modifiers", getModifiers(config.Display.Units))
+	r.PUT("/modifiers", updateModifiers(config.Display.Units))
 	r.POST("/modifiers/save", saveModifiers)
+	r.GET("/modifiers/clubs", getAllClubModifiers(config.Display.Units))
+	r.GET("/modifiers/clubs/:club", getClubModifiers(config.Display.Units))
+	r.PUT("/modifiers/clubs/:club", updateClubModifiers(config.Display.Units))
+	r.DELETE("/modifiers/clubs/:club", deleteClubModifiers)
+	r.GET("/rules", getRules(rules))
+	r.POST("/rules/validate", validateRule)
 	r.GET("/stats-image", getStatsImage)
 	r.GET("/logs", getLogs(config.Bridge.LogFile))
 	r.POST("/upload", handleUploads)
@@ -212,6 +289,27 @@ func Serve(logger *zap.Logger, config Shared.Config, cam Cameras.CameraControlle
 	r.POST("/camera/save", saveCamera(cam))
 	r.POST("/camera/delete", deleteCamera(cam))
 
+	r.POST("/replay/next", nextReplayShot(lm))
+	r.GET("/replay/status", getReplayStatus(lm))
+
+	r.GET("/simulator/status", getSimulatorStatus(sim))
+	r.GET("/shots/recent", getRecentShots(config.Display.Units))
+	r.GET("/units", getUnits(config.Display.Units))
+
+	r.GET("/quarantine", getQuarantine(quarantine, config.Display.Units))
+	r.PUT("/quarantine/:id", editQuarantinedShot(quarantine, config.Display.Units))
+	r.DELETE("/quarantine/:id", discardQuarantinedShot(quarantine))
+	r.POST("/quarantine/:id/release", releaseQuarantinedShot(quarantine, config.Display.Units))
+
+	r.GET("/clubs", getClubs)
+	r.POST("/virtual/shot", postVirtualShot(lm, config.Display.Units, false))
+	r.POST("/virtual/shot/data", postVirtualShot(lm, config.Display.Units, true))
+	r.GET("/virtual/ws", virtualShotSocket(lm, config.Display.Units))
+
+	r.POST("/putting/putt", postPutt(lm, config.Display.Units))
+	r.GET("/putting/mode", getPuttingMode(lm))
+	r.PUT("/putting/mode", putPuttingMode(lm))
+
 	// Serve HTML pages
 	r.GET("/tv", func(c *gin.Context) {
 		c.File("Assets/tv.html")
@@ -219,6 +317,9 @@ func Serve(logger *zap.Logger, config Shared.Config, cam Cameras.CameraControlle
 	r.GET("/settings", func(c *gin.Context) {
 		c.File("Assets/settings.html")
 	})
+	r.GET("/putting", func(c *gin.Context) {
+		c.File("Assets/putting.html")
+	})
 
 	address := fmt.Sprintf("%s:%d", config.HTTP.IPAddress, config.HTTP.Port)
 	log.Infof("Server running on http://%s", address)
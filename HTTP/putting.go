@@ -0,0 +1,97 @@
+package HTTP
+
+import (
+	"Fairway_Bridge/Launch_Monitors"
+	"Fairway_Bridge/Shared"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// maxPuttSpeed is the fastest putt accepted, in mph; a 60 ft putt on fast greens is about 12 mph.
+	maxPuttSpeed = 25.0
+	// maxPuttHLA is the widest putt direction accepted, in degrees either side of the target line.
+	maxPuttHLA = 20.0
+)
+
+// PuttRequest represents a putt: ball speed in the display units and direction in degrees, negative to the left.
+type PuttRequest struct {
+	Speed float64 `json:"Speed"`
+	HLA   float64 `json:"HLA"`
+}
+
+// PuttResponse represents the putt that was sent and the simulator's result, in the display units.
+type PuttResponse struct {
+	Speed  float64            `json:"Speed"`
+	HLA    float64            `json:"HLA"`
+	Result *Shared.ShotResult `json:"Result,omitempty"`
+}
+
+// PuttingMode represents whether putting mode is on.
+type PuttingMode struct {
+	Active bool `json:"Active"`
+}
+
+// postPutt handles POST /putting/putt
+func postPutt(lm Launch_Monitors.LaunchMonitorController, units Shared.UnitSystem) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		putting, ok := Launch_Monitors.Find[Launch_Monitors.PuttingController](lm)
+		if !ok {
+			c.JSON(http.StatusConflict, gin.H{"error": "Launch monitor does not support putts"})
+			return
+		}
+
+		var req PuttRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON"})
+			return
+		}
+		speed := Shared.ConvertSpeed(req.Speed, units, Shared.StandardUnits)
+		if speed <= 0 || speed > maxPuttSpeed {
+			maxSpeed := Shared.ConvertSpeed(maxPuttSpeed, Shared.StandardUnits, units)
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Speed must be between 0 and %.4g %s", maxSpeed, units.SpeedUnit())})
+			return
+		}
+		if math.Abs(req.HLA) > maxPuttHLA {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "HLA must be between -20 and 20 degrees"})
+			return
+		}
+
+		result := putting.TriggerPutt(speed, req.HLA)
+		c.JSON(http.StatusOK, PuttResponse{Speed: req.Speed, HLA: req.HLA, Result: resultIn(result, units)})
+	}
+}
+
+// getPuttingMode handles GET /putting/mode
+func getPuttingMode(lm Launch_Monitors.LaunchMonitorController) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		putting, ok := Launch_Monitors.Find[Launch_Monitors.PuttingController](lm)
+		if !ok {
+			c.JSON(http.StatusConflict, gin.H{"error": "Launch monitor does not support putts"})
+			return
+		}
+		c.JSON(http.StatusOK, PuttingMode{Active: putting.PuttingMode()})
+	}
+}
+
+// putPuttingMode handles PUT /putting/mode
+func putPuttingMode(lm Launch_Monitors.LaunchMonitorController) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		putting, ok := Launch_Monitors.Find[Launch_Monitors.PuttingController](lm)
+		if !ok {
+			c.JSON(http.StatusConflict, gin.H{"error": "Launch monitor does not support putts"})
+			return
+		}
+
+		var mode PuttingMode
+		if err := c.ShouldBindJSON(&mode); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON"})
+			return
+		}
+		putting.SetPuttingMode(mode.Active)
+		c.JSON(http.StatusOK, PuttingMode{Active: putting.PuttingMode()})
+	}
+}
@@ -0,0 +1,144 @@
+package HTTP
+
+import (
+	"Fairway_Bridge/Router"
+	"Fairway_Bridge/Shared"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+// EditQuarantinedShotRequest represents changes to a quarantined shot, in the display units.
+// Fields that are left out keep their value.
+type EditQuarantinedShotRequest struct {
+	ClubType *string                      `json:"ClubType"`
+	Ball     *Shared.StandardizedBallData `json:"Ball"`
+	Club     *Shared.StandardizedClubData `json:"Club"`
+}
+
+// ReleaseResponse represents a released shot's result from the simulator, in the display units.
+type ReleaseResponse struct {
+	Result *Shared.ShotResult `json:"Result,omitempty"`
+}
+
+// DiscardResponse represents whether discarding a shot also deleted its recording.
+type DiscardResponse struct {
+	RecordingDeleted bool `json:"RecordingDeleted"`
+}
+
+// quarantinedIn converts a quarantined shot to the given units.
+func quarantinedIn(shot Router.QuarantinedShot, units Shared.UnitSystem) Router.QuarantinedShot {
+	shot.Ball = Shared.ConvertBallUnits(shot.Ball, Shared.StandardUnits, units)
+	shot.Club = Shared.ConvertClubUnits(shot.Club, Shared.StandardUnits, units)
+	return shot
+}
+
+// quarantineID returns the shot ID in the request path, or writes an error when it is not a number.
+func quarantineID(c *gin.Context) (int, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid shot ID %s", c.Param("id"))})
+		return 0, false
+	}
+	return id, true
+}
+
+// quarantineError writes the error of a quarantine operation.
+func quarantineError(c *gin.Context, err error) {
+	if errors.Is(err, Router.ErrShotNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+}
+
+// getQuarantine handles GET /quarantine
+func getQuarantine(quarantine *Router.Quarantine, units Shared.UnitSystem) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		shots := quarantine.List()
+		for i := range shots {
+			shots[i] = quarantinedIn(shots[i], units)
+		}
+		c.JSON(http.StatusOK, shots)
+	}
+}
+
+// releaseQuarantinedShot handles POST /quarantine/:id/release
+func releaseQuarantinedShot(quarantine *Router.Quarantine, units Shared.UnitSystem) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := quarantineID(c)
+		if !ok {
+			return
+		}
+		result, err := quarantine.Release(id)
+		if err != nil {
+			quarantineError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, ReleaseResponse{Result: resultIn(result, units)})
+	}
+}
+
+// editQuarantinedShot handles PUT /quarantine/:id
+func editQuarantinedShot(quarantine *Router.Quarantine, units Shared.UnitSystem) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := quarantineID(c)
+		if !ok {
+			return
+		}
+		shot, err := quarantine.Get(id)
+		if err != nil {
+			quarantineError(c, err)
+			return
+		}
+
+		// The body is decoded over the shot so fields it leaves out keep their value.
+		shown := quarantinedIn(shot, units)
+		req := EditQuarantinedShotRequest{ClubType: &shown.ClubType, Ball: &shown.Ball, Club: &shown.Club}
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON"})
+			return
+		}
+		if req.ClubType != nil && *req.ClubType != "" && !Shared.ValidClubTypes[Shared.ClubType(*req.ClubType)] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("unknown club type %s", *req.ClubType)})
+			return
+		}
+
+		clubType, ball, club := shot.ClubType, shot.Ball, shot.Club
+		if req.ClubType != nil {
+			clubType = *req.ClubType
+		}
+		if req.Ball != nil {
+			ball = Shared.ConvertBallUnits(*req.Ball, units, Shared.StandardUnits)
+		}
+		if req.Club != nil {
+			club = Shared.ConvertClubUnits(*req.Club, units, Shared.StandardUnits)
+		}
+
+		shot, err = quarantine.Edit(id, ball, club, clubType)
+		if err != nil {
+			quarantineError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, quarantinedIn(shot, units))
+	}
+}
+
+// discardQuarantinedShot handles DELETE /quarantine/:id
+func discardQuarantinedShot(quarantine *Router.Quarantine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, ok := quarantineID(c)
+		if !ok {
+			return
+		}
+		deleted, err := quarantine.Discard(id)
+		if err != nil {
+			quarantineError(c, err)
+			return
+		}
+		c.JSON(http.StatusOK, DiscardResponse{RecordingDeleted: deleted})
+	}
+}
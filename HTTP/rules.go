@@ -0,0 +1,65 @@
+package HTTP
+
+import (
+	"Fairway_Bridge/Rules"
+	"Fairway_Bridge/Shared"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RuleDryRun is what a rule would have done to one recent shot, in mph and yards as rules are written.
+type RuleDryRun struct {
+	Timestamp time.Time      `json:"Timestamp"`
+	ClubType  string         `json:"ClubType"`
+	Outcome   *Rules.Outcome `json:"Outcome,omitempty"`
+	Error     string         `json:"Error,omitempty"`
+}
+
+// ValidateRuleResponse represents a valid rule and what it would have done to the recent shots, newest first.
+type ValidateRuleResponse struct {
+	Rule    Rules.Rule   `json:"Rule"`
+	Matched int          `json:"Matched"`
+	Shots   []RuleDryRun `json:"Shots"`
+}
+
+// getRules handles GET /rules
+func getRules(engine *Rules.Engine) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, engine.Status())
+	}
+}
+
+// validateRule handles POST /rules/validate
+// The rule is compiled and dry-run against the recent shots without changing them or the rules in use.
+func validateRule(c *gin.Context) {
+	var rule Rules.Rule
+	if err := c.ShouldBindJSON(&rule); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON"})
+		return
+	}
+	compiled, err := Rules.Compile(rule)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	response := ValidateRuleResponse{Rule: rule, Shots: []RuleDryRun{}}
+	for _, recent := range Shared.GetRecentShots() {
+		dryRun := RuleDryRun{Timestamp: recent.Timestamp, ClubType: recent.ClubType}
+		// The shot is taken as the rules saw it; flags are only set by rules, so it had none yet.
+		shot := &Rules.Shot{Ball: recent.RulesBall, Club: recent.RulesClub, ClubType: recent.ClubType, Metrics: recent.Metrics}
+		outcome, err := compiled.Apply(shot)
+		if err != nil {
+			dryRun.Error = err.Error()
+		} else {
+			dryRun.Outcome = &outcome
+			if outcome.Matched {
+				response.Matched++
+			}
+		}
+		response.Shots = append(response.Shots, dryRun)
+	}
+	c.JSON(http.StatusOK, response)
+}
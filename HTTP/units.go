@@ -0,0 +1,31 @@
+package HTTP
+
+import (
+	"Fairway_Bridge/Shared"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// UnitsResponse describes the units the HTTP API and the web pages use for speeds and distances.
+type UnitsResponse struct {
+	System   Shared.UnitSystem `json:"System"`
+	Speed    string            `json:"Speed"`
+	Distance string            `json:"Distance"`
+}
+
+// getUnits handles GET /units
+func getUnits(units Shared.UnitSystem) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.JSON(http.StatusOK, UnitsResponse{System: units, Speed: units.SpeedUnit(), Distance: units.DistanceUnit()})
+	}
+}
+
+// resultIn converts an optional shot result from the standard units.
+func resultIn(result *Shared.ShotResult, units Shared.UnitSystem) *Shared.ShotResult {
+	if result == nil {
+		return nil
+	}
+	converted := Shared.ConvertShotResultUnits(*result, Shared.StandardUnits, units)
+	return &converted
+}
@@ -0,0 +1,146 @@
+package HTTP
+
+import (
+	"Fairway_Bridge/Launch_Monitors"
+	"Fairway_Bridge/Shared"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"golang.org/x/net/websocket"
+)
+
+// VirtualShotRequest represents a request to fire a virtual shot, in the display units.
+// Requests with ball data fire that exact shot, otherwise a shot is generated for the distance.
+type VirtualShotRequest struct {
+	ClubType string                       `json:"ClubType,omitempty"`
+	Distance float64                      `json:"Distance,omitempty"`
+	BallData *Shared.StandardizedBallData `json:"BallData,omitempty"`
+	ClubData *Shared.StandardizedClubData `json:"ClubData,omitempty"`
+}
+
+// VirtualShotResponse represents the shot that was fired and the simulator's result, in the display units.
+type VirtualShotResponse struct {
+	ClubType string                      `json:"ClubType"`
+	BallData Shared.StandardizedBallData `json:"BallData"`
+	ClubData Shared.StandardizedClubData `json:"ClubData"`
+	Result   *Shared.ShotResult          `json:"Result,omitempty"`
+	Error    string                      `json:"Error,omitempty"`
+}
+
+// getClubs handles GET /clubs
+func getClubs(c *gin.Context) {
+	c.JSON(http.StatusOK, Shared.ClubTypes)
+}
+
+// fireVirtualShot validates the request and fires it through the trigger, converting from and to the display units.
+func fireVirtualShot(trigger Launch_Monitors.ShotTrigger, req VirtualShotRequest, units Shared.UnitSystem) (VirtualShotResponse, error) {
+	clubType := Shared.ClubType(req.ClubType)
+	if clubType != "" && !Shared.ValidClubTypes[clubType] {
+		return VirtualShotResponse{}, fmt.Errorf("unknown club type %s", req.ClubType)
+	}
+
+	if req.BallData != nil {
+		if clubType == "" {
+			return VirtualShotResponse{}, fmt.Errorf("a club type is required with ball data")
+		}
+		var clubData Shared.StandardizedClubData
+		if req.ClubData != nil {
+			clubData = *req.ClubData
+		}
+		result := trigger.TriggerShot(
+			Shared.ConvertBallUnits(*req.BallData, units, Shared.StandardUnits),
+			Shared.ConvertClubUnits(clubData, units, Shared.StandardUnits),
+			clubType,
+		)
+		return VirtualShotResponse{ClubType: string(clubType), BallData: *req.BallData, ClubData: clubData, Result: resultIn(result, units)}, nil
+	}
+
+	if req.Distance <= 0 {
+		return VirtualShotResponse{}, fmt.Errorf("distance must be greater than zero")
+	}
+	ballData, clubData, clubType, result := trigger.TriggerDistanceShot(clubType, Shared.ConvertDistance(req.Distance, units, Shared.StandardUnits))
+	return VirtualShotResponse{
+		ClubType: string(clubType),
+		BallData: Shared.ConvertBallUnits(ballData, Shared.StandardUnits, units),
+		ClubData: Shared.ConvertClubUnits(clubData, Shared.StandardUnits, units),
+		Result:   resultIn(result, units),
+	}, nil
+}
+
+// postVirtualShot handles POST /virtual/shot and POST /virtual/shot/data
+func postVirtualShot(lm Launch_Monitors.LaunchMonitorController, units Shared.UnitSystem, requireData bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		trigger, ok := Launch_Monitors.Find[Launch_Monitors.ShotTrigger](lm)
+		if !ok {
+			c.JSON(http.StatusConflict, gin.H{"error": "Launch monitor does not support shot requests"})
+			return
+		}
+
+		var req VirtualShotRequest
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid JSON"})
+			return
+		}
+		if requireData && req.BallData == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "BallData is required"})
+			return
+		}
+		if !requireData {
+			req.BallData, req.ClubData = nil, nil
+		}
+
+		resp, err := fireVirtualShot(trigger, req, units)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		c.JSON(http.StatusOK, resp)
+	}
+}
+
+// virtualShotSocket handles GET /virtual/ws
+// Each JSON VirtualShotRequest received on the socket fires a shot and is answered with a VirtualShotResponse.
+func virtualShotSocket(lm Launch_Monitors.LaunchMonitorController, units Shared.UnitSystem) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		trigger, ok := Launch_Monitors.Find[Launch_Monitors.ShotTrigger](lm)
+		if !ok {
+			c.JSON(http.StatusConflict, gin.H{"error": "Launch monitor does not support shot requests"})
+			return
+		}
+
+		websocket.Server{Handshake: checkSameOrigin, Handler: func(ws *websocket.Conn) {
+			for {
+				var req VirtualShotRequest
+				if err := websocket.JSON.Receive(ws, &req); err != nil {
+					return
+				}
+				resp, err := fireVirtualShot(trigger, req, units)
+				if err != nil {
+					resp.Error = err.Error()
+				}
+				if err := websocket.JSON.Send(ws, resp); err != nil {
+					return
+				}
+			}
+		}}.ServeHTTP(c.Writer, c.Request)
+	}
+}
+
+// checkSameOrigin rejects WebSocket handshakes whose Origin is not the host the request was sent to,
+// so other web pages open in the browser cannot fire shots.
+func checkSameOrigin(config *websocket.Config, req *http.Request) error {
+	origin, err := websocket.Origin(config, req)
+	if err != nil {
+		return err
+	}
+	if origin == nil {
+		return fmt.Errorf("missing origin")
+	}
+	if !strings.EqualFold(origin.Host, req.Host) {
+		return fmt.Errorf("origin %s does not match host %s", origin.Host, req.Host)
+	}
+	config.Origin = origin
+	return nil
+}
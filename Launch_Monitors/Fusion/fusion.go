@@ -0,0 +1,251 @@
+package Fusion
+
+import (
+	"Fairway_Bridge/Launch_Monitors"
+	"Fairway_Bridge/Shared"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// fieldKeys lists the policy key of every ball and club field, e.g. BALL.TOTALSPIN.
+var fieldKeys = func() map[string]bool {
+	keys := map[string]bool{}
+	for _, f := range Shared.BallFields {
+		keys["BALL."+strings.ToUpper(f.Name)] = true
+	}
+	for _, f := range Shared.ClubFields {
+		keys["CLUB."+strings.ToUpper(f.Name)] = true
+	}
+	return keys
+}()
+
+// Device is one of the launch monitors being fused.
+type Device struct {
+	Name       string
+	Controller Launch_Monitors.LaunchMonitorController
+}
+
+// measurement is one device's value for a field.
+type measurement struct {
+	device string
+	value  float64
+}
+
+// group collects the shots that arrived within one fusion window.
+type group struct {
+	shots   []Shared.DeviceShot
+	options []Shared.ShotDataOptions
+	once    sync.Once
+	done    chan struct{}
+	result  *Shared.ShotResult
+}
+
+// LaunchMonitor fuses several launch monitors into one shot stream.
+// Shots that arrive within the window are merged field by field using the policy,
+// and every device's raw shot is kept in the ShotDataOptions sources.
+type LaunchMonitor struct {
+	devices []Device
+	window  time.Duration
+	policy  Policy
+	pending *group
+	mutex   sync.Mutex
+	log     *zap.SugaredLogger
+	onShot  Shared.ShotHandlerFunc
+}
+
+// NewLaunchMonitor creates a launch monitor that fuses the given devices.
+func NewLaunchMonitor(logger *zap.Logger, devices []Device, window time.Duration, policy Policy) *LaunchMonitor {
+	log := logger.With(zap.String("component", "LAUNCH_MONITOR"), zap.String("type", "FUSION")).Sugar()
+	return &LaunchMonitor{
+		devices: devices,
+		window:  window,
+		policy:  policy,
+		log:     log,
+	}
+}
+
+// Connect wires each device's shots into the fusion window.
+// The devices are expected to be connected already.
+func (lm *LaunchMonitor) Connect() error {
+	names := make([]string, len(lm.devices))
+	for i, device := range lm.devices {
+		device.Controller.SetOnShotCallback(lm.deviceCallback(device))
+		names[i] = device.Name
+	}
+	lm.log.Infof("✅ fusing shots from %s within %s", strings.Join(names, ", "), lm.window)
+	return nil
+}
+
+// Close closes every fused device.
+func (lm *LaunchMonitor) Close() error {
+	var errs []error
+	for _, device := range lm.devices {
+		if err := device.Controller.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LaunchShot is a no-op as shots are triggered by the fused devices.
+func (lm *LaunchMonitor) LaunchShot() {
+	lm.log.Infof("shots are triggered by the fused launch monitors")
+}
+
+// SetOnShotCallback sets the callback function for fused shots.
+func (lm *LaunchMonitor) SetOnShotCallback(f Shared.ShotHandlerFunc) {
+	lm.onShot = f
+}
+
+// Convention returns the standard convention, as device shots are converted before they are merged.
+func (lm *LaunchMonitor) Convention() Shared.Convention {
+	return Shared.StandardConvention
+}
+
+// LaunchMonitors returns the fused devices.
+func (lm *LaunchMonitor) LaunchMonitors() []Launch_Monitors.LaunchMonitorController {
+	controllers := make([]Launch_Monitors.LaunchMonitorController, len(lm.devices))
+	for i, device := range lm.devices {
+		controllers[i] = device.Controller
+	}
+	return controllers
+}
+
+// RelayResponse forwards a simulator response to every device that relays responses.
+func (lm *LaunchMonitor) RelayResponse(response Shared.SimulatorResponse) {
+	for _, device := range lm.devices {
+		if relay, ok := device.Controller.(Launch_Monitors.ResponseRelay); ok {
+			relay.RelayResponse(response)
+		}
+	}
+}
+
+// SetClubType passes a simulator club change to every device that follows it.
+func (lm *LaunchMonitor) SetClubType(clubType Shared.ClubType) {
+	for _, device := range lm.devices {
+		if setter, ok := device.Controller.(Launch_Monitors.ClubSetter); ok {
+			setter.SetClubType(clubType)
+		}
+	}
+}
+
+// deviceCallback returns the shot callback for one device.
+// It blocks until the fused shot has been handled so the device receives the simulator's result.
+func (lm *LaunchMonitor) deviceCallback(device Device) Shared.ShotHandlerFunc {
+	return func(ball Shared.StandardizedBallData, club Shared.StandardizedClubData, options Shared.ShotDataOptions) *Shared.ShotResult {
+		handed := Shared.GetHandedness()
+		shot := Shared.DeviceShot{
+			Device:   device.Name,
+			ClubType: options.ClubType,
+			Ball:     Shared.ConvertBallData(ball, device.Controller.Convention(), Shared.StandardConvention, handed),
+			Club:     Shared.ConvertClubData(club, device.Controller.Convention(), Shared.StandardConvention, handed),
+		}
+		lm.log.Infof("received shot from %s", device.Name)
+
+		g := lm.add(shot, options)
+		<-g.done
+		return g.result
+	}
+}
+
+// add places a shot in the pending group, starting a new window when needed.
+func (lm *LaunchMonitor) add(shot Shared.DeviceShot, options Shared.ShotDataOptions) *group {
+	lm.mutex.Lock()
+	defer lm.mutex.Unlock()
+
+	// A second shot from the same device belongs to the next swing.
+	if lm.pending != nil {
+		for _, s := range lm.pending.shots {
+			if s.Device == shot.Device {
+				go lm.flush(lm.pending)
+				lm.pending = nil
+				break
+			}
+		}
+	}
+
+	if lm.pending == nil {
+		g := &group{done: make(chan struct{})}
+		lm.pending = g
+		time.AfterFunc(lm.window, func() { lm.flush(g) })
+	}
+	g := lm.pending
+	g.shots = append(g.shots, shot)
+	g.options = append(g.options, options)
+
+	// Every device has reported, so there is nothing left to wait for.
+	if len(g.shots) == len(lm.devices) {
+		lm.pending = nil
+		go lm.flush(g)
+	}
+	return g
+}
+
+// flush merges a group and passes the fused shot to the shot callback, once.
+func (lm *LaunchMonitor) flush(g *group) {
+	g.once.Do(func() {
+		lm.mutex.Lock()
+		if lm.pending == g {
+			lm.pending = nil
+		}
+		lm.mutex.Unlock()
+
+		ball, club, options := lm.merge(g)
+		devices := make([]string, len(g.shots))
+		for i, shot := range g.shots {
+			devices[i] = shot.Device
+		}
+		lm.log.Infof("⛳️ fused shot from %s", strings.Join(devices, ", "))
+
+		if lm.onShot != nil {
+			g.result = lm.onShot(ball, club, options)
+		} else {
+			lm.log.Warnf("onShotCallback is nil, skipping callback invocation")
+		}
+		close(g.done)
+	})
+}
+
+// merge combines the shots of a group using the policy.
+// A device without data of a kind, e.g. no club data, is treated as not measuring its fields.
+// A zero is a measurement, as a straight HLA or path is 0.
+func (lm *LaunchMonitor) merge(g *group) (Shared.StandardizedBallData, Shared.StandardizedClubData, Shared.ShotDataOptions) {
+	var ball Shared.StandardizedBallData
+	var club Shared.StandardizedClubData
+	var options Shared.ShotDataOptions
+
+	for _, f := range Shared.BallFields {
+		var values []measurement
+		for i, shot := range g.shots {
+			if g.options[i].ContainsBallData {
+				values = append(values, measurement{shot.Device, *f.Value(&shot.Ball)})
+			}
+		}
+		*f.Value(&ball) = merge(lm.policy.strategy("BALL."+strings.ToUpper(f.Name)), values)
+	}
+	for _, f := range Shared.ClubFields {
+		var values []measurement
+		for i, shot := range g.shots {
+			if g.options[i].ContainsClubData {
+				values = append(values, measurement{shot.Device, *f.Value(&shot.Club)})
+			}
+		}
+		*f.Value(&club) = merge(lm.policy.strategy("CLUB."+strings.ToUpper(f.Name)), values)
+	}
+
+	for _, o := range g.options {
+		options.ContainsBallData = options.ContainsBallData || o.ContainsBallData
+		options.ContainsClubData = options.ContainsClubData || o.ContainsClubData
+		options.LaunchMonitorIsReady = options.LaunchMonitorIsReady || o.LaunchMonitorIsReady
+		options.LaunchMonitorBallDetected = options.LaunchMonitorBallDetected || o.LaunchMonitorBallDetected
+		if options.ClubType == "" {
+			options.ClubType = o.ClubType
+		}
+	}
+	options.Sources = g.shots
+	return ball, club, options
+}
@@ -0,0 +1,133 @@
+package Fusion
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const (
+	// PolicyFirst takes the value from the first device that measured the field.
+	PolicyFirst = "FIRST"
+	// PolicyAverage averages the values of every device that measured the field.
+	PolicyAverage = "AVERAGE"
+	// PolicyMin takes the lowest measured value.
+	PolicyMin = "MIN"
+	// PolicyMax takes the highest measured value.
+	PolicyMax = "MAX"
+)
+
+// fieldGroups lists the shorthand names that apply a policy to several fields at once.
+var fieldGroups = map[string][]string{
+	"SPIN":   {"BALL.SPINAXIS", "BALL.TOTALSPIN", "BALL.BACKSPIN", "BALL.SIDESPIN"},
+	"SPEED":  {"BALL.SPEED", "CLUB.SPEED", "CLUB.SPEEDATIMPACT"},
+	"LAUNCH": {"BALL.HLA", "BALL.VLA"},
+}
+
+// Policy decides how each field is merged when several devices report the same shot.
+// A policy is either one of the named strategies or the name of the device to prefer.
+type Policy struct {
+	rules   map[string]string
+	devices map[string]bool
+}
+
+// ParsePolicy parses a policy such as "spin=R10,speed=average,default=first".
+// Keys are a field (ball.TotalSpin, club.Path), a group (spin, speed, launch), ball, club or default.
+func ParsePolicy(value string, devices []string) (Policy, error) {
+	policy := Policy{rules: map[string]string{"DEFAULT": PolicyFirst}, devices: map[string]bool{}}
+	for _, device := range devices {
+		policy.devices[device] = true
+	}
+
+	for _, rule := range strings.Split(value, ",") {
+		rule = strings.TrimSpace(rule)
+		if rule == "" {
+			continue
+		}
+		key, strategy, ok := strings.Cut(rule, "=")
+		if !ok {
+			return policy, fmt.Errorf("policy rule %q must look like field=strategy", rule)
+		}
+		key = strings.ToUpper(strings.TrimSpace(key))
+		strategy = strings.ToUpper(strings.TrimSpace(strategy))
+
+		if !validKey(key) {
+			return policy, fmt.Errorf("unknown policy field %s", key)
+		}
+		switch strategy {
+		case PolicyFirst, PolicyAverage, PolicyMin, PolicyMax:
+		default:
+			if !policy.devices[strategy] {
+				return policy, fmt.Errorf("policy strategy %s is neither first, average, min, max nor a fused launch monitor", strategy)
+			}
+		}
+		policy.rules[key] = strategy
+	}
+	return policy, nil
+}
+
+// validKey reports whether key names a field, a group, a data set or the default.
+func validKey(key string) bool {
+	switch key {
+	case "DEFAULT", "BALL", "CLUB":
+		return true
+	}
+	if _, ok := fieldGroups[key]; ok {
+		return true
+	}
+	return fieldKeys[key]
+}
+
+// strategy returns the strategy for a field key such as BALL.TOTALSPIN, from the most to the least specific rule.
+func (p Policy) strategy(field string) string {
+	if s, ok := p.rules[field]; ok {
+		return s
+	}
+	for group, fields := range fieldGroups {
+		if s, ok := p.rules[group]; ok && slices.Contains(fields, field) {
+			return s
+		}
+	}
+	set, _, _ := strings.Cut(field, ".")
+	if s, ok := p.rules[set]; ok {
+		return s
+	}
+	return p.rules["DEFAULT"]
+}
+
+// merge combines the measured values of a field, in arrival order, using the strategy.
+func merge(strategy string, values []measurement) float64 {
+	if len(values) == 0 {
+		return 0
+	}
+	switch strategy {
+	case PolicyFirst:
+		return values[0].value
+	case PolicyAverage:
+		var sum float64
+		for _, v := range values {
+			sum += v.value
+		}
+		return sum / float64(len(values))
+	case PolicyMin:
+		result := values[0].value
+		for _, v := range values[1:] {
+			result = min(result, v.value)
+		}
+		return result
+	case PolicyMax:
+		result := values[0].value
+		for _, v := range values[1:] {
+			result = max(result, v.value)
+		}
+		return result
+	default:
+		// Prefer the named device, falling back to the first one when it did not measure the field.
+		for _, v := range values {
+			if v.device == strategy {
+				return v.value
+			}
+		}
+		return values[0].value
+	}
+}
@@ -0,0 +1,179 @@
+package GSPro_OpenConnect
+
+import (
+	"Fairway_Bridge/Shared"
+	"Fairway_Bridge/Simulators/GSPro"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// LaunchMonitor accepts connections from GSPro Open Connect v1 connectors and
+// treats the shots they send as launch monitor input.
+type LaunchMonitor struct {
+	port           int
+	localIP        string
+	listener       net.Listener
+	client         net.Conn
+	clientUnits    Shared.UnitSystem
+	clientMutex    sync.Mutex
+	log            *zap.SugaredLogger
+	onShotCallback Shared.ShotHandlerFunc
+}
+
+// NewLaunchMonitor creates a new instance of LaunchMonitor.
+func NewLaunchMonitor(localIP string, port int, logger *zap.Logger) *LaunchMonitor {
+	log := logger.With(zap.String("component", "LAUNCH_MONITOR"), zap.String("type", "OPENCONNECT")).Sugar()
+	log.Info("initializing GSPro Open Connect server")
+	return &LaunchMonitor{
+		localIP: localIP,
+		port:    port,
+		log:     log,
+	}
+}
+
+// Connect starts the TCP server and listens for connector connections.
+func (oc *LaunchMonitor) Connect() error {
+	addr := net.JoinHostPort(oc.localIP, strconv.Itoa(oc.port))
+	oc.log.Infof("binding to address %s", addr)
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		oc.log.Errorf("starting server: %v", err)
+		return fmt.Errorf("starting server: %w", err)
+	}
+	oc.listener = ln
+	oc.log.Infof("server listening at %s", addr)
+
+	go func() {
+		for {
+			oc.log.Infof("waiting for a new connector...")
+			conn, err := oc.listener.Accept()
+			if err != nil {
+				if errors.Is(err, net.ErrClosed) {
+					oc.log.Warnf("listener closed, stopping accept loop")
+					break
+				}
+				oc.log.Errorf("accepting connection: %v", err)
+				continue
+			}
+			oc.log.Infof("connector accepted from %s", conn.RemoteAddr().String())
+			oc.handleConnection(conn)
+		}
+	}()
+
+	return nil
+}
+
+// Close stops the server and closes the connector connection.
+func (oc *LaunchMonitor) Close() error {
+	oc.handleDisconnect()
+	if oc.listener == nil {
+		return nil
+	}
+	return oc.listener.Close()
+}
+
+// handleConnection decodes shot messages from the connector until it disconnects.
+func (oc *LaunchMonitor) handleConnection(conn net.Conn) {
+	oc.clientMutex.Lock()
+	oc.client = conn
+	oc.clientUnits = Shared.StandardUnits
+	oc.clientMutex.Unlock()
+
+	// GSPro messages are plain JSON objects, so a streaming decoder handles both
+	// newline-delimited and back-to-back messages.
+	decoder := json.NewDecoder(conn)
+	for {
+		var msg GSPro.ShotMessage
+		if err := decoder.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) || errors.Is(err, net.ErrClosed) {
+				oc.log.Infof("connector closed the connection")
+			} else {
+				oc.log.Errorf("decoding shot message: %v", err)
+			}
+			break
+		}
+		oc.handleShotMessage(msg)
+	}
+	oc.handleDisconnect()
+}
+
+// handleShotMessage converts a connector shot and passes it to the shot callback.
+func (oc *LaunchMonitor) handleShotMessage(msg GSPro.ShotMessage) {
+	if msg.ShotDataOptions.IsHeartBeat {
+		oc.log.Debugf("received heartbeat from connector %s (ready=%t)", msg.DeviceID, msg.ShotDataOptions.LaunchMonitorIsReady)
+		return
+	}
+	if !msg.ShotDataOptions.ContainsBallData {
+		oc.log.Warnf("received shot %d from %s without ball data, ignoring", msg.ShotNumber, msg.DeviceID)
+		return
+	}
+	oc.log.Infof("received shot %d from connector %s (units=%s, api=%s)", msg.ShotNumber, msg.DeviceID, msg.Units, msg.APIversion)
+
+	// Connectors may send metric shots; they are converted here and responses are relayed back in the same units.
+	units := GSPro.ConvertUnitsToStandard(msg.Units)
+	oc.clientMutex.Lock()
+	oc.clientUnits = units
+	oc.clientMutex.Unlock()
+	standardBall, standardClub := GSPro.ConvertToStandard(msg.BallData, msg.ClubData)
+	standardBall = Shared.ConvertBallUnits(standardBall, units, Shared.StandardUnits)
+	standardClub = Shared.ConvertClubUnits(standardClub, units, Shared.StandardUnits)
+	if oc.onShotCallback != nil {
+		oc.onShotCallback(standardBall, standardClub, msg.ShotDataOptions)
+	} else {
+		oc.log.Warnf("onShotCallback is nil, skipping callback invocation")
+	}
+}
+
+// RelayResponse forwards a simulator response to the connected connector, in the units of its last shot.
+func (oc *LaunchMonitor) RelayResponse(response Shared.SimulatorResponse) {
+	oc.clientMutex.Lock()
+	defer oc.clientMutex.Unlock()
+	if oc.client == nil {
+		oc.log.Warnf("no connector connected to relay response")
+		return
+	}
+
+	data, err := json.Marshal(GSPro.ConvertResponseToSimulator(response, oc.clientUnits))
+	if err != nil {
+		oc.log.Errorf("marshaling response: %v", err)
+		return
+	}
+	if _, err := oc.client.Write(append(data, '\n')); err != nil {
+		oc.log.Errorf("relaying response: %v", err)
+		return
+	}
+	oc.log.Infof("relayed response to connector: %s", data)
+}
+
+// SetOnShotCallback sets the callback function for shot events.
+func (oc *LaunchMonitor) SetOnShotCallback(callback Shared.ShotHandlerFunc) {
+	oc.onShotCallback = callback
+}
+
+// Convention returns the Open Connect data convention, which matches GSPro's.
+func (oc *LaunchMonitor) Convention() Shared.Convention {
+	return Shared.StandardConvention
+}
+
+// LaunchShot is a no-op as shots are triggered by the connector.
+func (oc *LaunchMonitor) LaunchShot() {
+	oc.log.Infof("shots are triggered by the connected connector")
+}
+
+// handleDisconnect cleans up the connector connection.
+func (oc *LaunchMonitor) handleDisconnect() {
+	oc.clientMutex.Lock()
+	defer oc.clientMutex.Unlock()
+	if oc.client != nil {
+		_ = oc.client.Close()
+		oc.client = nil
+		oc.log.Infof("connector disconnected")
+	}
+}
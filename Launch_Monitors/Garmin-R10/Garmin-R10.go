@@ -1,11 +1,14 @@
 package Garmin_R10
 
 import (
+	E6 "Fairway_Bridge/Protocols/E6"
 	"Fairway_Bridge/Shared"
 	"bufio"
 	"encoding/json"
 	"fmt"
+	"math"
 	"net"
+	"sync"
 	"time"
 
 	"go.uber.org/zap"
@@ -14,6 +17,8 @@ import (
 const (
 	heartbeatInterval   = 10 * time.Second
 	pingTimeoutDuration = 3 * time.Second
+	feetPerYard         = 3.0
+	feetPerSecondPerMPH = 5280.0 / 3600.0
 )
 
 // LaunchMonitor encapsulates the R10 server and connection state.
@@ -23,13 +28,15 @@ type LaunchMonitor struct {
 	localIP         string
 	listener        net.Listener
 	client          net.Conn
+	clientMutex     sync.Mutex
 	log             *zap.SugaredLogger
 	didReceivePong  bool
 	pingTimer       *time.Timer
 	heartbeatTicker *time.Ticker
-	ballData        BallData
-	clubData        ClubData
+	ballData        E6.BallData
+	clubData        E6.ClubData
 	clubType        ClubType
+	clubMutex       sync.Mutex
 	onShotCallback  Shared.ShotHandlerFunc
 }
 
@@ -91,14 +98,17 @@ func (r *LaunchMonitor) Close() error {
 // handleConnection handles a new client connection using a bufio.Scanner with a custom JSON split.
 func (r *LaunchMonitor) handleConnection(conn net.Conn) {
 	r.log.Infof("established a new connection")
-	r.client = conn
 	r.log.Debugf("client remote address: %s", conn.RemoteAddr().String())
 
 	// Start heartbeat: send a ping every heartbeatInterval.
-	r.heartbeatTicker = time.NewTicker(heartbeatInterval)
+	ticker := time.NewTicker(heartbeatInterval)
+	r.clientMutex.Lock()
+	r.client = conn
+	r.heartbeatTicker = ticker
+	r.clientMutex.Unlock()
 	go func() {
 		r.log.Debugf("heartbeat goroutine started, interval: %s", heartbeatInterval.String())
-		for range r.heartbeatTicker.C {
+		for range ticker.C {
 			r.log.Debugf("heartbeat ticker tick at %s; sending ping...", time.Now().Format(time.RFC3339Nano))
 			r.sendPing()
 		}
@@ -160,7 +170,8 @@ func (r *LaunchMonitor) handleIncomingData(msg Message) {
 		r.setClubData(msg.ClubData)
 	case "SendShot":
 		r.log.Infof("received SendShot")
-		go r.sendShot()
+		// The shot is copied here as the read loop goes on to store the next shot while this one is processed.
+		go r.sendShot(r.ballData, r.clubData)
 	default:
 		r.log.Warnf("no match for message type: %s", msg.Type)
 	}
@@ -169,32 +180,45 @@ func (r *LaunchMonitor) handleIncomingData(msg Message) {
 // updateClubType updates the club type and replies with success.
 func (r *LaunchMonitor) updateClubType(clubType ClubType) {
 	r.log.Infof("changing club type to %s", clubType)
-	r.clubType = clubType
+	r.setClubType(clubType)
 	r.sendMessage(getSuccessMessage("SetClubType"))
 }
 
+// SetClubType follows a club change made in the simulator and pushes it to the device.
+func (r *LaunchMonitor) SetClubType(clubType Shared.ClubType) {
+	if r.getClubType() == clubType {
+		return
+	}
+	r.log.Infof("simulator changed club to %s, updating the R10", clubType)
+	r.setClubType(clubType)
+	r.sendMessage(getSetClubTypeCommand(clubType))
+}
+
+// getClubType returns the current club type.
+func (r *LaunchMonitor) getClubType() ClubType {
+	r.clubMutex.Lock()
+	defer r.clubMutex.Unlock()
+	return r.clubType
+}
+
+// setClubType records the current club type.
+func (r *LaunchMonitor) setClubType(clubType ClubType) {
+	r.clubMutex.Lock()
+	defer r.clubMutex.Unlock()
+	r.clubType = clubType
+}
+
 // setBallData converts and saves ball data then replies with success.
-func (r *LaunchMonitor) setBallData(bd *BallData) {
+func (r *LaunchMonitor) setBallData(bd *E6.BallData) {
 	if bd == nil {
 		r.log.Errorf("received nil ball data")
 		return
 	}
 	r.log.Infof("received ball data: %+v", bd)
 
-	spinAxis := bd.SpinAxis
-
-	// Modify the spinAxis based on the simulator type.
-	if r.config.LaunchMonitor.Name == "GSPro" {
-		// Convert from 0° to 360° to -90° to 90° range
-		if spinAxis > 90 {
-			spinAxis -= 360
-		}
-		spinAxis = spinAxis * -1
-	}
-
-	r.ballData = BallData{
+	r.ballData = E6.BallData{
 		BallSpeed:       bd.BallSpeed,
-		SpinAxis:        spinAxis,
+		SpinAxis:        bd.SpinAxis,
 		TotalSpin:       bd.TotalSpin,
 		LaunchDirection: bd.LaunchDirection,
 		LaunchAngle:     bd.LaunchAngle,
@@ -204,7 +228,7 @@ func (r *LaunchMonitor) setBallData(bd *BallData) {
 }
 
 // setClubData saves club data and replies with success.
-func (r *LaunchMonitor) setClubData(cd *ClubData) {
+func (r *LaunchMonitor) setClubData(cd *E6.ClubData) {
 	if cd == nil {
 		r.log.Infof("received nil club data")
 		return
@@ -214,31 +238,43 @@ func (r *LaunchMonitor) setClubData(cd *ClubData) {
 	r.sendMessage(getSuccessMessage("SetClubData"))
 }
 
-// sendShot sends the shot sequence.
-func (r *LaunchMonitor) sendShot() {
-	r.log.Infof("processing shot with ballData: %+v and clubData: %+v", r.ballData, r.clubData)
+// sendShot sends the shot sequence for a copy of the received ball and club data.
+func (r *LaunchMonitor) sendShot(ballData E6.BallData, clubData E6.ClubData) {
+	clubType := r.getClubType()
+	r.log.Infof("processing %s shot with ballData: %+v and clubData: %+v", clubType, ballData, clubData)
 	r.sendMessage(getSuccessMessage("SendShot"))
-	time.AfterFunc(300*time.Millisecond, func() {
-		r.log.Infof("sending ShotComplete message")
-		r.sendMessage(getShotCompleteMessage())
-	})
 
 	// Invoke the callback with the converted data.
 	r.log.Infof("invoking shot callback")
-	standardBall, standardClub := ConvertToStandard(r.ballData, r.clubData)
+	standardBall, standardClub := E6.ConvertToStandard(ballData, clubData)
 	r.log.Debugf("converted shot -> standardBall: %+v, standardClub: %+v", standardBall, standardClub)
+	var shotResult *Shared.ShotResult
 	if r.onShotCallback != nil {
-		r.onShotCallback(standardBall, standardClub, Shared.ShotDataOptions{
+		shotResult = r.onShotCallback(standardBall, standardClub, Shared.ShotDataOptions{
 			ContainsBallData:          true,
 			ContainsClubData:          true,
 			LaunchMonitorBallDetected: true,
-			ClubType:                  string(r.clubType),
+			ClubType:                  string(clubType),
 		})
 	} else {
 		r.log.Warnf("onShotCallback is nil, skipping callback invocation")
 	}
 
+	// Prefer the simulator's result and fall back to the ball-flight model.
+	handed := Shared.GetHandedness()
+	if shotResult == nil {
+		r.log.Infof("simulator did not report a result, estimating ball flight")
+		estimate := Shared.EstimateFlight(Shared.ConvertBallData(standardBall, r.Convention(), Shared.StandardConvention, handed), clubType)
+		shotResult = &estimate
+	}
+	nativeResult := Shared.ConvertShotResult(*shotResult, Shared.StandardConvention, r.Convention(), handed)
+	shotComplete := getShotCompleteMessage(ballData, clubData, clubType, nativeResult)
+
 	// Respond to the launch monitor to ready for the next shot.
+	time.AfterFunc(300*time.Millisecond, func() {
+		r.log.Infof("sending ShotComplete message")
+		r.sendMessage(shotComplete)
+	})
 	time.AfterFunc(700*time.Millisecond, func() {
 		r.log.Infof("sending Disarm command")
 		r.sendMessage(getSimCommand("Disarm"))
@@ -249,6 +285,11 @@ func (r *LaunchMonitor) sendShot() {
 	})
 }
 
+// Convention returns the R10's native data convention, which is the E6 convention.
+func (r *LaunchMonitor) Convention() Shared.Convention {
+	return E6.Convention
+}
+
 // SetOnShotCallback sets the callback function for shot events.
 func (r *LaunchMonitor) SetOnShotCallback(callback Shared.ShotHandlerFunc) {
 	r.onShotCallback = callback
@@ -257,6 +298,8 @@ func (r *LaunchMonitor) SetOnShotCallback(callback Shared.ShotHandlerFunc) {
 // handlePong marks that a pong was received.
 func (r *LaunchMonitor) handlePong() {
 	r.log.Infof("pong received, resetting ping timer")
+	r.clientMutex.Lock()
+	defer r.clientMutex.Unlock()
 	r.didReceivePong = true
 	if r.pingTimer != nil {
 		stopped := r.pingTimer.Stop()
@@ -267,10 +310,15 @@ func (r *LaunchMonitor) handlePong() {
 // sendPing sends a ping and starts a timeout.
 func (r *LaunchMonitor) sendPing() {
 	r.log.Infof("initiating ping at %s", time.Now().Format(time.RFC3339Nano))
+	r.clientMutex.Lock()
+	client := r.client
 	r.didReceivePong = false
-	if r.client != nil {
-		r.log.Infof("sending ping to client at %s", r.client.RemoteAddr().String())
+	r.clientMutex.Unlock()
+	if client != nil {
+		r.log.Infof("sending ping to client at %s", client.RemoteAddr().String())
 		r.sendMessage(getSimCommand("Ping"))
+		r.clientMutex.Lock()
+		defer r.clientMutex.Unlock()
 		// If an existing pingTimer exists, stop it and log that event.
 		if r.pingTimer != nil {
 			stopped := r.pingTimer.Stop()
@@ -278,7 +326,10 @@ func (r *LaunchMonitor) sendPing() {
 		}
 		r.pingTimer = time.AfterFunc(pingTimeoutDuration, func() {
 			r.log.Debugf("ping timeout check at %s (duration %s)", time.Now().Format(time.RFC3339Nano), pingTimeoutDuration.String())
-			if !r.didReceivePong {
+			r.clientMutex.Lock()
+			answered := r.didReceivePong
+			r.clientMutex.Unlock()
+			if !answered {
 				r.log.Warnf("ping timeout - the R10 has stopped responding")
 				r.handleDisconnect()
 			} else {
@@ -294,6 +345,8 @@ func (r *LaunchMonitor) sendPing() {
 // sendMessage writes a JSON message (with newline) to the client.
 func (r *LaunchMonitor) sendMessage(msg string) {
 	r.log.Infof("preparing to send message: %s", msg)
+	r.clientMutex.Lock()
+	defer r.clientMutex.Unlock()
 	if r.client != nil {
 		n, err := r.client.Write([]byte(msg + "\n"))
 		if err != nil {
@@ -309,6 +362,8 @@ func (r *LaunchMonitor) sendMessage(msg string) {
 // handleDisconnect cleans up the client connection.
 func (r *LaunchMonitor) handleDisconnect() {
 	r.log.Infof("disconnecting client...")
+	r.clientMutex.Lock()
+	defer r.clientMutex.Unlock()
 	if r.client != nil {
 		_ = r.client.Close()
 		r.client = nil
@@ -362,37 +417,53 @@ func getSimCommand(cmd string) string {
 	return string(data)
 }
 
-// getShotCompleteMessage returns a fixed ShotComplete message.
-func getShotCompleteMessage() string {
+// getSetClubTypeCommand returns a SimCommand asking the device to switch to the given club.
+func getSetClubTypeCommand(clubType ClubType) string {
+	msg := map[string]interface{}{
+		"ClubType": clubType,
+		"SubType":  "SetClubType",
+		"Type":     "SimCommand",
+	}
+	data, _ := json.Marshal(msg)
+	return string(data)
+}
+
+// getShotCompleteMessage returns a ShotComplete message for the measured shot and its result.
+// E6 reports distances in feet, club head speed in feet per second and angles in the 0° to 360° range.
+func getShotCompleteMessage(ball E6.BallData, club E6.ClubData, clubType ClubType, result Shared.ShotResult) string {
+	spinAxis := ball.SpinAxis * math.Pi / 180
+	smashFactor := 0.0
+	if club.ClubHeadSpeed > 0 {
+		smashFactor = ball.BallSpeed / club.ClubHeadSpeed
+	}
+
 	msg := map[string]interface{}{
 		"Details": map[string]interface{}{
-			"Apex": 62.2087860107422,
+			"Apex": result.Apex * feetPerYard,
 			"BallData": map[string]interface{}{
-				"BackSpin":        4690.28662109375,
-				"BallSpeed":       151.587356567383,
-				"LaunchAngle":     17.7735958099365,
-				"LaunchDirection": -5.00650501251221,
-				"SideSpin":        -542.832092285156,
-				"SpinAxis":        353.398223876953,
-				"TotalSpin":       4721.59423828125,
+				"BackSpin":        ball.TotalSpin * math.Cos(spinAxis),
+				"BallSpeed":       ball.BallSpeed,
+				"LaunchAngle":     ball.LaunchAngle,
+				"LaunchDirection": ball.LaunchDirection,
+				"SideSpin":        ball.TotalSpin * math.Sin(spinAxis),
+				"SpinAxis":        ball.SpinAxis,
+				"TotalSpin":       ball.TotalSpin,
 			},
 			"BallInHole":          false,
-			"BallLocation":        "Fringe",
-			"CarryDeviationAngle": 357.429321289063,
-			"CarryDeviationFeet":  -19.5566101074219,
-			"CarryDistance":       436.027191162109,
+			"CarryDeviationAngle": deviationAngle(result.CarryDeviation, result.CarryDistance),
+			"CarryDeviationFeet":  result.CarryDeviation * feetPerYard,
+			"CarryDistance":       result.CarryDistance * feetPerYard,
 			"ClubData": map[string]interface{}{
-				"ClubAngleFace":    -2.42121529579163,
-				"ClubAnglePath":    -10.2835702896118,
-				"ClubHeadSpeed":    110.317367553711,
-				"ClubHeadSpeedMPH": 75.2163848876953,
-				"ClubType":         "7Iron",
-				"SmashFactor":      1.37410235404968,
+				"ClubAngleFace":    club.ClubAngleFace,
+				"ClubAnglePath":    club.ClubAnglePath,
+				"ClubHeadSpeed":    club.ClubHeadSpeed * feetPerSecondPerMPH,
+				"ClubHeadSpeedMPH": club.ClubHeadSpeed,
+				"ClubType":         clubType,
+				"SmashFactor":      smashFactor,
 			},
-			"DistanceToPin":       122.404106140137,
-			"TotalDeviationAngle": 356.053466796875,
-			"TotalDeviationFeet":  -32.0723648071289,
-			"TotalDistance":       465.995697021484,
+			"TotalDeviationAngle": deviationAngle(result.TotalDeviation, result.TotalDistance),
+			"TotalDeviationFeet":  result.TotalDeviation * feetPerYard,
+			"TotalDistance":       result.TotalDistance * feetPerYard,
 		},
 		"SubType": "ShotComplete",
 		"Type":    "SimCommand",
@@ -401,8 +472,17 @@ func getShotCompleteMessage() string {
 	return string(data)
 }
 
+// deviationAngle returns the angle of a deviation from the target line in the 0° to 360° range.
+func deviationAngle(deviation float64, distance float64) float64 {
+	angle := math.Atan2(deviation, distance) * 180 / math.Pi
+	if angle < 0 {
+		angle += 360
+	}
+	return angle
+}
+
 // LaunchShot simulates launching a shot.
 func (r *LaunchMonitor) LaunchShot() {
 	r.log.Infof("simulating shot launch")
-	r.sendShot()
+	r.sendShot(r.ballData, r.clubData)
 }
@@ -0,0 +1,214 @@
+package Generic_JSON
+
+import (
+	"Fairway_Bridge/Launch_Monitors/Mapping"
+	"Fairway_Bridge/Shared"
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// ProtocolTCP accepts newline-delimited JSON over TCP connections.
+	ProtocolTCP = "TCP"
+	// ProtocolUDP accepts JSON datagrams, each holding one or more newline-delimited messages.
+	ProtocolUDP = "UDP"
+
+	// maxMessageSize is the largest message accepted from the device.
+	maxMessageSize = 64 * 1024
+)
+
+// LaunchMonitor listens for newline-delimited JSON shots from devices that have no dedicated adapter.
+// The mapping file decides which JSON values feed each standardized field.
+type LaunchMonitor struct {
+	port           int
+	localIP        string
+	protocol       string
+	mappingFile    string
+	mapping        *Mapping.Mapping
+	listener       net.Listener
+	packetConn     net.PacketConn
+	clients        map[net.Conn]struct{}
+	clientMutex    sync.Mutex
+	log            *zap.SugaredLogger
+	onShotCallback Shared.ShotHandlerFunc
+}
+
+// NewLaunchMonitor creates a new instance of LaunchMonitor.
+func NewLaunchMonitor(localIP string, port int, logger *zap.Logger, config Shared.Config) *LaunchMonitor {
+	log := logger.With(zap.String("component", "LAUNCH_MONITOR"), zap.String("type", "JSON")).Sugar()
+	log.Info("initializing generic JSON launch monitor")
+	return &LaunchMonitor{
+		localIP:     localIP,
+		port:        port,
+		protocol:    config.JSON.Protocol,
+		mappingFile: config.Mapping.File,
+		clients:     make(map[net.Conn]struct{}),
+		log:         log,
+	}
+}
+
+// Connect loads the mapping file and starts listening for the device.
+func (lm *LaunchMonitor) Connect() error {
+	if lm.mappingFile == "" {
+		return fmt.Errorf("a mapping file is required")
+	}
+	mapping, err := Mapping.Load(lm.mappingFile)
+	if err != nil {
+		return fmt.Errorf("loading mapping: %w", err)
+	}
+	lm.mapping = mapping
+	lm.log.Infof("✅ loaded mapping from %s", lm.mappingFile)
+
+	addr := net.JoinHostPort(lm.localIP, strconv.Itoa(lm.port))
+	switch lm.protocol {
+	case ProtocolTCP:
+		ln, err := net.Listen("tcp", addr)
+		if err != nil {
+			return fmt.Errorf("starting server: %w", err)
+		}
+		lm.listener = ln
+		go lm.acceptLoop()
+	case ProtocolUDP:
+		conn, err := net.ListenPacket("udp", addr)
+		if err != nil {
+			return fmt.Errorf("starting server: %w", err)
+		}
+		lm.packetConn = conn
+		go lm.readPackets()
+	default:
+		return fmt.Errorf("protocol %s is not supported", lm.protocol)
+	}
+	lm.log.Infof("server listening for %s messages at %s", lm.protocol, addr)
+	return nil
+}
+
+// Close stops the server and closes every device connection.
+func (lm *LaunchMonitor) Close() error {
+	lm.clientMutex.Lock()
+	for conn := range lm.clients {
+		_ = conn.Close()
+	}
+	lm.clientMutex.Unlock()
+
+	if lm.packetConn != nil {
+		return lm.packetConn.Close()
+	}
+	if lm.listener != nil {
+		return lm.listener.Close()
+	}
+	return nil
+}
+
+// acceptLoop accepts TCP connections until the listener is closed.
+func (lm *LaunchMonitor) acceptLoop() {
+	for {
+		conn, err := lm.listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				lm.log.Warnf("listener closed, stopping accept loop")
+				return
+			}
+			lm.log.Errorf("accepting connection: %v", err)
+			continue
+		}
+		lm.log.Infof("device connected from %s", conn.RemoteAddr().String())
+		go lm.handleConnection(conn)
+	}
+}
+
+// handleConnection reads newline-delimited messages from a device until it disconnects.
+func (lm *LaunchMonitor) handleConnection(conn net.Conn) {
+	lm.clientMutex.Lock()
+	lm.clients[conn] = struct{}{}
+	lm.clientMutex.Unlock()
+
+	lm.readMessages(conn)
+
+	lm.clientMutex.Lock()
+	delete(lm.clients, conn)
+	lm.clientMutex.Unlock()
+	_ = conn.Close()
+	lm.log.Infof("device %s disconnected", conn.RemoteAddr().String())
+}
+
+// readPackets reads UDP datagrams until the connection is closed.
+func (lm *LaunchMonitor) readPackets() {
+	buffer := make([]byte, maxMessageSize)
+	for {
+		n, addr, err := lm.packetConn.ReadFrom(buffer)
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				lm.log.Warnf("connection closed, stopping read loop")
+				return
+			}
+			lm.log.Errorf("reading datagram: %v", err)
+			continue
+		}
+		lm.log.Debugf("received %d bytes from %s", n, addr.String())
+		lm.readMessages(bytes.NewReader(buffer[:n]))
+	}
+}
+
+// readMessages handles each line of the reader as a device message.
+func (lm *LaunchMonitor) readMessages(r io.Reader) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxMessageSize)
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		lm.handleMessage(line)
+	}
+	if err := scanner.Err(); err != nil && !errors.Is(err, net.ErrClosed) {
+		lm.log.Errorf("reading messages: %v", err)
+	}
+}
+
+// handleMessage maps a device message to a shot and passes it to the shot callback.
+func (lm *LaunchMonitor) handleMessage(line []byte) {
+	msg, err := lm.mapping.Decode(line)
+	if err != nil {
+		lm.log.Errorf("decoding message: %v", err)
+		return
+	}
+
+	ballData, clubData, options, ok := lm.mapping.Apply(msg)
+	if !ok {
+		lm.log.Debugf("ignoring message without a shot: %s", line)
+		return
+	}
+	lm.log.Infof("⛳️ received shot (club=%s, speed=%.1f)", options.ClubType, ballData.Speed)
+
+	if lm.onShotCallback != nil {
+		lm.onShotCallback(ballData, clubData, options)
+	} else {
+		lm.log.Warnf("onShotCallback is nil, skipping callback invocation")
+	}
+}
+
+// SetOnShotCallback sets the callback function for shot events.
+func (lm *LaunchMonitor) SetOnShotCallback(callback Shared.ShotHandlerFunc) {
+	lm.onShotCallback = callback
+}
+
+// Convention returns the convention declared in the mapping file.
+func (lm *LaunchMonitor) Convention() Shared.Convention {
+	if lm.mapping == nil {
+		return Shared.StandardConvention
+	}
+	return lm.mapping.Convention()
+}
+
+// LaunchShot is a no-op as shots are triggered by the device.
+func (lm *LaunchMonitor) LaunchShot() {
+	lm.log.Infof("shots are triggered by the connected device")
+}
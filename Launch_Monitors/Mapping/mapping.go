@@ -0,0 +1,392 @@
+package Mapping
+
+import (
+	"Fairway_Bridge/Shared"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Mapping describes how messages from a launch monitor are turned into standardized shot data.
+// It is loaded from a YAML or JSON file so new devices can be supported without writing an adapter.
+type Mapping struct {
+	Format    string                  `json:"format"`
+	Columns   []string                `json:"columns"`
+	Delimiter string                  `json:"delimiter"`
+	Ball      map[string]FieldMapping `json:"ball"`
+	Club      map[string]FieldMapping `json:"club"`
+	ClubType  string                  `json:"club_type"`
+	Clubs     map[string]string       `json:"clubs"`
+	Filter    *Filter                 `json:"filter"`
+	Device    ConventionMapping       `json:"convention"`
+
+	convention Shared.Convention
+}
+
+const (
+	// FormatJSON decodes each message as a JSON document and reads values by dotted path.
+	FormatJSON = "json"
+	// FormatCSV decodes each message as one delimited record and reads values by column name or number.
+	FormatCSV = "csv"
+)
+
+// FieldMapping gives the path a field is read from and how the value is converted to standard units.
+// The value is converted as (value * scale + offset) in the given unit.
+type FieldMapping struct {
+	Path   string   `json:"path"`
+	Unit   string   `json:"unit"`
+	Scale  *float64 `json:"scale"`
+	Offset float64  `json:"offset"`
+}
+
+// Filter only accepts messages whose value at Path equals Equals, e.g. to ignore heartbeats.
+type Filter struct {
+	Path   string `json:"path"`
+	Equals string `json:"equals"`
+}
+
+// ConventionMapping describes the device's data convention using readable names.
+type ConventionMapping struct {
+	SpinAxisRange     string `json:"spin_axis_range"`
+	SpinAxisDirection string `json:"spin_axis_direction"`
+	HLADirection      string `json:"hla_direction"`
+	Framing           string `json:"framing"`
+}
+
+// unitKind groups units that measure the same quantity.
+type unitKind int
+
+const (
+	kindNone unitKind = iota
+	kindSpeed
+	kindAngle
+	kindSpin
+	kindDistance
+)
+
+// unit converts a device unit to the standard unit of its kind.
+type unit struct {
+	kind   unitKind
+	factor float64
+}
+
+// units lists the supported units and their factor to the standard unit (mph, degrees, rpm, yards).
+var units = map[string]unit{
+	"mph": {kindSpeed, 1},
+	"mps": {kindSpeed, 2.2369362920544},
+	"kph": {kindSpeed, 0.62137119223733},
+	"fps": {kindSpeed, 0.68181818181818},
+	"deg": {kindAngle, 1},
+	"rad": {kindAngle, 180 / math.Pi},
+	"rpm": {kindSpin, 1},
+	"rps": {kindSpin, 60},
+	"yd":  {kindDistance, 1},
+	"m":   {kindDistance, 1.0936132983377},
+	"ft":  {kindDistance, 1.0 / 3},
+}
+
+// ballKinds gives the kind of unit each mappable ball field takes.
+var ballKinds = map[string]unitKind{
+	"Speed":         kindSpeed,
+	"SpinAxis":      kindAngle,
+	"TotalSpin":     kindSpin,
+	"BackSpin":      kindSpin,
+	"SideSpin":      kindSpin,
+	"HLA":           kindAngle,
+	"VLA":           kindAngle,
+	"CarryDistance": kindDistance,
+}
+
+// clubKinds gives the kind of unit each mappable club field takes.
+var clubKinds = map[string]unitKind{
+	"Speed":                kindSpeed,
+	"AngleOfAttack":        kindAngle,
+	"FaceToTarget":         kindAngle,
+	"Lie":                  kindAngle,
+	"Loft":                 kindAngle,
+	"Path":                 kindAngle,
+	"SpeedAtImpact":        kindSpeed,
+	"VerticalFaceImpact":   kindNone,
+	"HorizontalFaceImpact": kindNone,
+	"ClosureRate":          kindNone,
+}
+
+// Load reads a mapping from a YAML or JSON file and validates it.
+func Load(path string) (*Mapping, error) {
+	var m Mapping
+	if err := Shared.ReadConfigFile(path, &m); err != nil {
+		return nil, err
+	}
+	if err := m.validate(); err != nil {
+		return nil, fmt.Errorf("mapping %s: %w", path, err)
+	}
+	return &m, nil
+}
+
+// validate checks that every mapped field, unit and convention name is known.
+func (m *Mapping) validate() error {
+	m.Format = strings.ToLower(m.Format)
+	switch m.Format {
+	case "":
+		m.Format = FormatJSON
+	case FormatJSON, FormatCSV:
+	default:
+		return fmt.Errorf("unknown format %s (json, csv)", m.Format)
+	}
+	if len([]rune(m.Delimiter)) > 1 {
+		return fmt.Errorf("delimiter must be a single character")
+	}
+	if _, ok := m.Ball["Speed"]; !ok {
+		return fmt.Errorf("ball Speed must be mapped")
+	}
+	for name, fm := range m.Ball {
+		kind, ok := ballKinds[name]
+		if !ok {
+			return fmt.Errorf("unknown ball field %s", name)
+		}
+		if err := fm.validate(kind); err != nil {
+			return fmt.Errorf("ball %s: %w", name, err)
+		}
+	}
+	for name, fm := range m.Club {
+		kind, ok := clubKinds[name]
+		if !ok {
+			return fmt.Errorf("unknown club field %s", name)
+		}
+		if err := fm.validate(kind); err != nil {
+			return fmt.Errorf("club %s: %w", name, err)
+		}
+	}
+	for device, clubType := range m.Clubs {
+		if !Shared.ValidClubTypes[Shared.ClubType(clubType)] {
+			return fmt.Errorf("club %s maps to unknown club type %s", device, clubType)
+		}
+	}
+	if m.Filter != nil && m.Filter.Path == "" {
+		return fmt.Errorf("filter needs a path")
+	}
+	convention, err := m.Device.parse()
+	m.convention = convention
+	return err
+}
+
+// validate checks the field has a path and a unit of the right kind.
+func (fm FieldMapping) validate(kind unitKind) error {
+	if fm.Path == "" {
+		return fmt.Errorf("missing path")
+	}
+	if fm.Unit == "" {
+		return nil
+	}
+	u, ok := units[strings.ToLower(fm.Unit)]
+	if !ok {
+		return fmt.Errorf("unknown unit %s", fm.Unit)
+	}
+	if u.kind != kind {
+		return fmt.Errorf("unit %s does not apply to this field", fm.Unit)
+	}
+	return nil
+}
+
+// convert applies the scale, offset and unit conversion to a raw value.
+func (fm FieldMapping) convert(value float64) float64 {
+	if fm.Scale != nil {
+		value *= *fm.Scale
+	}
+	value += fm.Offset
+	if u, ok := units[strings.ToLower(fm.Unit)]; ok {
+		value *= u.factor
+	}
+	return value
+}
+
+// parse converts the readable convention names, defaulting to the standard convention.
+func (cm ConventionMapping) parse() (Shared.Convention, error) {
+	c := Shared.StandardConvention
+	switch strings.ToLower(cm.SpinAxisRange) {
+	case "", "signed":
+	case "unsigned":
+		c.SpinAxisRange = Shared.SpinAxisUnsigned
+	default:
+		return c, fmt.Errorf("unknown spin_axis_range %s (signed, unsigned)", cm.SpinAxisRange)
+	}
+	for _, d := range []struct {
+		name  string
+		value string
+		dir   *Shared.Direction
+	}{
+		{"spin_axis_direction", cm.SpinAxisDirection, &c.SpinAxisDirection},
+		{"hla_direction", cm.HLADirection, &c.HLADirection},
+	} {
+		switch strings.ToLower(d.value) {
+		case "", "right":
+		case "left":
+			*d.dir = Shared.LeftPositive
+		default:
+			return c, fmt.Errorf("unknown %s %s (right, left)", d.name, d.value)
+		}
+	}
+	switch strings.ToLower(cm.Framing) {
+	case "", "target":
+	case "golfer":
+		c.Framing = Shared.GolferFraming
+	default:
+		return c, fmt.Errorf("unknown framing %s (target, golfer)", cm.Framing)
+	}
+	return c, nil
+}
+
+// Convention returns the device convention described by the mapping.
+func (m *Mapping) Convention() Shared.Convention {
+	return m.convention
+}
+
+// Decode parses one message in the mapping's format so it can be passed to Apply.
+// CSV records are keyed by column number and, when columns are named, by column name.
+func (m *Mapping) Decode(message []byte) (interface{}, error) {
+	if m.Format != FormatCSV {
+		var msg interface{}
+		err := json.Unmarshal(message, &msg)
+		return msg, err
+	}
+
+	reader := csv.NewReader(bytes.NewReader(message))
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+	if m.Delimiter != "" {
+		reader.Comma = []rune(m.Delimiter)[0]
+	}
+	record, err := reader.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	msg := make(map[string]interface{}, len(record)*2)
+	for i, value := range record {
+		msg[strconv.Itoa(i)] = value
+		if i < len(m.Columns) && m.Columns[i] != "" {
+			msg[m.Columns[i]] = value
+		}
+	}
+	return msg, nil
+}
+
+// Apply extracts a shot from a decoded message.
+// It returns false when the message is filtered out or carries no ball speed.
+func (m *Mapping) Apply(msg interface{}) (Shared.StandardizedBallData, Shared.StandardizedClubData, Shared.ShotDataOptions, bool) {
+	var ballData Shared.StandardizedBallData
+	var clubData Shared.StandardizedClubData
+	var options Shared.ShotDataOptions
+
+	if m.Filter != nil {
+		value, ok := Lookup(msg, m.Filter.Path)
+		if !ok || fmt.Sprint(value) != m.Filter.Equals {
+			return ballData, clubData, options, false
+		}
+	}
+
+	found := map[string]bool{}
+	for _, f := range Shared.BallFields {
+		fm, ok := m.Ball[f.Name]
+		if !ok {
+			continue
+		}
+		if value, ok := lookupNumber(msg, fm.Path); ok {
+			*f.Value(&ballData) = fm.convert(value)
+			found[f.Name] = true
+		}
+	}
+	if !found["Speed"] || ballData.Speed <= 0 {
+		return ballData, clubData, options, false
+	}
+	deriveSpin(&ballData, found)
+
+	for _, f := range Shared.ClubFields {
+		fm, ok := m.Club[f.Name]
+		if !ok {
+			continue
+		}
+		if value, ok := lookupNumber(msg, fm.Path); ok {
+			*f.Value(&clubData) = fm.convert(value)
+			options.ContainsClubData = true
+		}
+	}
+
+	if m.ClubType != "" {
+		if value, ok := Lookup(msg, m.ClubType); ok {
+			options.ClubType = m.clubType(fmt.Sprint(value))
+		}
+	}
+
+	options.ContainsBallData = true
+	options.LaunchMonitorBallDetected = true
+	options.LaunchMonitorIsReady = true
+	return ballData, clubData, options, true
+}
+
+// clubType maps a device club name to a ClubType, falling back to the name itself when it is already valid.
+func (m *Mapping) clubType(name string) string {
+	if clubType, ok := m.Clubs[name]; ok {
+		return clubType
+	}
+	if Shared.ValidClubTypes[Shared.ClubType(name)] {
+		return name
+	}
+	return ""
+}
+
+// deriveSpin fills in total spin and spin axis for devices that only report back and side spin.
+func deriveSpin(ball *Shared.StandardizedBallData, found map[string]bool) {
+	if found["TotalSpin"] || !found["BackSpin"] {
+		return
+	}
+	ball.TotalSpin = math.Hypot(ball.BackSpin, ball.SideSpin)
+	if !found["SpinAxis"] {
+		ball.SpinAxis = math.Atan2(ball.SideSpin, ball.BackSpin) * 180 / math.Pi
+	}
+}
+
+// Lookup returns the value at a dotted path such as "shot.ball.speed" or "shots.0.speed".
+func Lookup(msg interface{}, path string) (interface{}, bool) {
+	current := msg
+	for _, key := range strings.Split(path, ".") {
+		switch node := current.(type) {
+		case map[string]interface{}:
+			value, ok := node[key]
+			if !ok {
+				return nil, false
+			}
+			current = value
+		case []interface{}:
+			i, err := strconv.Atoi(key)
+			if err != nil || i < 0 || i >= len(node) {
+				return nil, false
+			}
+			current = node[i]
+		default:
+			return nil, false
+		}
+	}
+	return current, current != nil
+}
+
+// lookupNumber returns the numeric value at a path, accepting numbers sent as strings.
+func lookupNumber(msg interface{}, path string) (float64, bool) {
+	value, ok := Lookup(msg, path)
+	if !ok {
+		return 0, false
+	}
+	switch v := value.(type) {
+	case float64:
+		return v, true
+	case string:
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		return f, err == nil
+	default:
+		return 0, false
+	}
+}
@@ -0,0 +1,110 @@
+package Plugin
+
+import (
+	"Fairway_Bridge/Shared"
+
+	"go.uber.org/zap"
+)
+
+// LaunchMonitor reads shots from an external plugin process that writes Shot messages to its stdout.
+// The plugin is restarted when it exits.
+type LaunchMonitor struct {
+	process        *Shared.PluginProcess
+	log            *zap.SugaredLogger
+	onShotCallback Shared.ShotHandlerFunc
+}
+
+// NewLaunchMonitor creates a launch monitor that runs the configured plugin command.
+func NewLaunchMonitor(logger *zap.Logger, config Shared.Config) *LaunchMonitor {
+	log := logger.With(zap.String("component", "LAUNCH_MONITOR"), zap.String("type", "PLUGIN")).Sugar()
+	lm := &LaunchMonitor{log: log}
+	lm.process = Shared.NewPluginProcess(config.Plugin.LaunchMonitorCommand, "LaunchMonitor", Shared.StandardUnits, log, lm.handleMessage, nil)
+	return lm
+}
+
+// Connect starts the plugin process.
+func (lm *LaunchMonitor) Connect() error {
+	return lm.process.Start()
+}
+
+// Close asks the plugin to exit and stops restarting it.
+func (lm *LaunchMonitor) Close() error {
+	lm.process.Stop()
+	return nil
+}
+
+// handleMessage passes each Shot from the plugin to the Router and answers it with a Result
+// carrying the simulator's outcome, when it reported one. Shots and results are in the shot's Units,
+// which default to the standard units.
+func (lm *LaunchMonitor) handleMessage(msg Shared.PluginMessage) {
+	if msg.Type != Shared.PluginShot {
+		lm.log.Warnf("ignoring %s message from launch monitor plugin", msg.Type)
+		return
+	}
+	if msg.BallData == nil {
+		lm.log.Warnf("ignoring shot %d without BallData", msg.ID)
+		return
+	}
+
+	var options Shared.ShotDataOptions
+	if msg.ShotDataOptions != nil {
+		options = *msg.ShotDataOptions
+	}
+	units := Shared.StandardUnits
+	if msg.Units != "" {
+		units = msg.Units
+	}
+	options.ContainsBallData = true
+	ballData := Shared.ConvertBallUnits(*msg.BallData, units, Shared.StandardUnits)
+	var clubData Shared.StandardizedClubData
+	if msg.ClubData != nil {
+		clubData = Shared.ConvertClubUnits(*msg.ClubData, units, Shared.StandardUnits)
+		options.ContainsClubData = true
+	}
+	if msg.ClubType != "" {
+		options.ClubType = string(msg.ClubType)
+	}
+	lm.log.Infof("⛳️ received shot %d (club=%s, speed=%.1f %s)", msg.ID, options.ClubType, msg.BallData.Speed, units.SpeedUnit())
+
+	if lm.onShotCallback == nil {
+		lm.log.Warnf("onShotCallback is nil, skipping callback invocation")
+		return
+	}
+	result := lm.onShotCallback(ballData, clubData, options)
+	if result != nil {
+		converted := Shared.ConvertShotResultUnits(*result, Shared.StandardUnits, units)
+		result = &converted
+	}
+	if err := lm.process.Send(Shared.PluginMessage{Type: Shared.PluginResult, ID: msg.ID, Units: units, Result: result}); err != nil {
+		lm.log.Errorf("answering shot %d: %v", msg.ID, err)
+	}
+}
+
+// RelayResponse forwards the simulator's responses to the plugin.
+func (lm *LaunchMonitor) RelayResponse(response Shared.SimulatorResponse) {
+	if err := lm.process.Send(Shared.PluginMessage{Type: Shared.PluginResponse, Code: response.Code, Message: response.Message}); err != nil {
+		lm.log.Debugf("relaying response to plugin: %v", err)
+	}
+}
+
+// SetClubType tells the plugin the club selected in the simulator.
+func (lm *LaunchMonitor) SetClubType(clubType Shared.ClubType) {
+	if err := lm.process.Send(Shared.PluginMessage{Type: Shared.PluginClub, ClubType: clubType}); err != nil {
+		lm.log.Debugf("sending club to plugin: %v", err)
+	}
+}
+
+// SetOnShotCallback sets the callback function for shot events.
+func (lm *LaunchMonitor) SetOnShotCallback(callback Shared.ShotHandlerFunc) {
+	lm.onShotCallback = callback
+}
+
+// Convention returns the standard convention; plugins report shots in it.
+func (lm *LaunchMonitor) Convention() Shared.Convention {
+	return Shared.StandardConvention
+}
+
+// LaunchShot is a no-op as shots are triggered by the plugin.
+func (lm *LaunchMonitor) LaunchShot() {
+	lm.log.Infof("shots are triggered by the plugin")
+}
@@ -0,0 +1,188 @@
+package Putting
+
+import (
+	"Fairway_Bridge/Launch_Monitors"
+	"Fairway_Bridge/Shared"
+	"errors"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// greenSurface is the surface GSPro reports in PlayerInfo when the ball is on the green.
+const greenSurface = "GREEN"
+
+// LaunchMonitor adds putting to a full swing launch monitor.
+// Putts come from the HTTP API and the putting page, or from a dedicated putting device, and are sent as
+// ball speed and direction only. While putting mode is on, shots from the full swing launch monitor are
+// sent as putts too. Putting mode follows the surface reported by the simulator when auto is enabled.
+type LaunchMonitor struct {
+	swing       Launch_Monitors.LaunchMonitorController
+	device      Launch_Monitors.LaunchMonitorController
+	auto        bool
+	active      bool
+	lastSurface string
+	stateMutex  sync.Mutex
+	shotMutex   sync.Mutex
+	log         *zap.SugaredLogger
+	onShot      Shared.ShotHandlerFunc
+}
+
+// NewLaunchMonitor wraps the full swing launch monitor and an optional dedicated putting device.
+// Both are expected to be connected already.
+func NewLaunchMonitor(logger *zap.Logger, swing Launch_Monitors.LaunchMonitorController, device Launch_Monitors.LaunchMonitorController, config Shared.Config) *LaunchMonitor {
+	log := logger.With(zap.String("component", "LAUNCH_MONITOR"), zap.String("type", "PUTTING")).Sugar()
+	return &LaunchMonitor{
+		swing:  swing,
+		device: device,
+		auto:   config.Putting.Auto,
+		log:    log,
+	}
+}
+
+// Shape turns a shot into a putt: ball speed and direction only, with no launch, spin or club data.
+func Shape(ball Shared.StandardizedBallData, options Shared.ShotDataOptions) (Shared.StandardizedBallData, Shared.StandardizedClubData, Shared.ShotDataOptions) {
+	putt := Shared.StandardizedBallData{Speed: ball.Speed, HLA: ball.HLA}
+	options.ContainsBallData = true
+	options.ContainsClubData = false
+	options.ClubType = string(Shared.Putter)
+	return putt, Shared.StandardizedClubData{}, options
+}
+
+// Connect wires the shots of the full swing launch monitor and the putting device.
+func (lm *LaunchMonitor) Connect() error {
+	lm.swing.SetOnShotCallback(func(ball Shared.StandardizedBallData, club Shared.StandardizedClubData, options Shared.ShotDataOptions) *Shared.ShotResult {
+		ball, club = lm.standardize(lm.swing, ball, club)
+		if lm.PuttingMode() {
+			lm.log.Infof("putting mode is on, sending the shot as a putt")
+			ball, club, options = Shape(ball, options)
+		}
+		return lm.shot(ball, club, options)
+	})
+	if lm.device != nil {
+		lm.device.SetOnShotCallback(func(ball Shared.StandardizedBallData, club Shared.StandardizedClubData, options Shared.ShotDataOptions) *Shared.ShotResult {
+			ball, _ = lm.standardize(lm.device, ball, club)
+			lm.log.Infof("⛳️ putt from the putting device (speed=%.1f, HLA=%.1f)", ball.Speed, ball.HLA)
+			ball, club, options = Shape(ball, options)
+			return lm.shot(ball, club, options)
+		})
+		lm.log.Infof("✅ taking putts from the putting device")
+	}
+	return nil
+}
+
+// standardize converts a member's shot to the standard convention.
+func (lm *LaunchMonitor) standardize(member Launch_Monitors.LaunchMonitorController, ball Shared.StandardizedBallData, club Shared.StandardizedClubData) (Shared.StandardizedBallData, Shared.StandardizedClubData) {
+	handed := Shared.GetHandedness()
+	return Shared.ConvertBallData(ball, member.Convention(), Shared.StandardConvention, handed),
+		Shared.ConvertClubData(club, member.Convention(), Shared.StandardConvention, handed)
+}
+
+// shot passes a shot to the Router, one at a time.
+func (lm *LaunchMonitor) shot(ball Shared.StandardizedBallData, club Shared.StandardizedClubData, options Shared.ShotDataOptions) *Shared.ShotResult {
+	lm.shotMutex.Lock()
+	defer lm.shotMutex.Unlock()
+	if lm.onShot == nil {
+		lm.log.Warnf("onShotCallback is nil, skipping callback invocation")
+		return nil
+	}
+	return lm.onShot(ball, club, options)
+}
+
+// TriggerPutt sends a putt with the given ball speed (mph) and horizontal launch angle (degrees).
+func (lm *LaunchMonitor) TriggerPutt(speed float64, hla float64) *Shared.ShotResult {
+	lm.log.Infof("⛳️ putt requested (speed=%.1f, HLA=%.1f)", speed, hla)
+	ball, club, options := Shape(Shared.StandardizedBallData{Speed: speed, HLA: hla}, Shared.ShotDataOptions{})
+	return lm.shot(ball, club, options)
+}
+
+// SetPuttingMode turns putting mode on or off.
+func (lm *LaunchMonitor) SetPuttingMode(active bool) {
+	lm.stateMutex.Lock()
+	changed := lm.active != active
+	lm.active = active
+	lm.stateMutex.Unlock()
+	if changed {
+		lm.log.Infof("putting mode %s", onOff(active))
+	}
+}
+
+// PuttingMode reports whether putting mode is on.
+func (lm *LaunchMonitor) PuttingMode() bool {
+	lm.stateMutex.Lock()
+	defer lm.stateMutex.Unlock()
+	return lm.active
+}
+
+// onOff describes a mode for the log.
+func onOff(active bool) string {
+	if active {
+		return "on"
+	}
+	return "off"
+}
+
+// RelayResponse follows the simulator's surface and forwards the response to the members.
+// Putting mode only changes when the surface does, so it can still be toggled by hand on any surface.
+func (lm *LaunchMonitor) RelayResponse(response Shared.SimulatorResponse) {
+	if player := response.Player; lm.auto && player != nil && player.Surface != "" {
+		surface := strings.ToUpper(player.Surface)
+		lm.stateMutex.Lock()
+		changed := surface != lm.lastSurface
+		lm.lastSurface = surface
+		lm.stateMutex.Unlock()
+		if changed {
+			lm.SetPuttingMode(surface == greenSurface)
+		}
+	}
+	for _, member := range lm.LaunchMonitors() {
+		if relay, ok := member.(Launch_Monitors.ResponseRelay); ok {
+			relay.RelayResponse(response)
+		}
+	}
+}
+
+// SetClubType passes a simulator club change to the members that follow it.
+func (lm *LaunchMonitor) SetClubType(clubType Shared.ClubType) {
+	for _, member := range lm.LaunchMonitors() {
+		if setter, ok := member.(Launch_Monitors.ClubSetter); ok {
+			setter.SetClubType(clubType)
+		}
+	}
+}
+
+// LaunchMonitors returns the full swing launch monitor and the putting device.
+func (lm *LaunchMonitor) LaunchMonitors() []Launch_Monitors.LaunchMonitorController {
+	members := []Launch_Monitors.LaunchMonitorController{lm.swing}
+	if lm.device != nil {
+		members = append(members, lm.device)
+	}
+	return members
+}
+
+// Close closes the full swing launch monitor and the putting device.
+func (lm *LaunchMonitor) Close() error {
+	var errs []error
+	for _, member := range lm.LaunchMonitors() {
+		if err := member.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// LaunchShot starts the full swing launch monitor.
+func (lm *LaunchMonitor) LaunchShot() {
+	lm.swing.LaunchShot()
+}
+
+// SetOnShotCallback sets the callback function for shots and putts.
+func (lm *LaunchMonitor) SetOnShotCallback(f Shared.ShotHandlerFunc) {
+	lm.onShot = f
+}
+
+// Convention returns the standard convention, as member shots are converted before they are passed on.
+func (lm *LaunchMonitor) Convention() Shared.Convention {
+	return Shared.StandardConvention
+}
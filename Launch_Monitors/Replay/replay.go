@@ -0,0 +1,202 @@
+package Replay
+
+import (
+	"Fairway_Bridge/Shared"
+	"Fairway_Bridge/Storage"
+	"fmt"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// ModeDelay replays shots with a fixed delay between them.
+	ModeDelay = "DELAY"
+	// ModeOriginal replays shots with the timing they were recorded with.
+	ModeOriginal = "ORIGINAL"
+	// ModeManual replays a shot each time Step is called.
+	ModeManual = "MANUAL"
+
+	// DataRaw replays the raw launch monitor data.
+	DataRaw = "RAW"
+	// DataAdjusted replays the data after modifiers were applied.
+	DataAdjusted = "ADJUSTED"
+)
+
+// LaunchMonitor re-fires shots recorded in a shot file.
+type LaunchMonitor struct {
+	log        *zap.SugaredLogger
+	file       string
+	shotFile   string
+	mode       string
+	data       string
+	delay      time.Duration
+	shots      []Storage.ShotRecord
+	position   int
+	mutex      sync.Mutex
+	onShot     Shared.ShotHandlerFunc
+	stepSignal chan struct{}
+	stopSignal chan struct{}
+}
+
+// NewLaunchMonitor initializes a replay launch monitor.
+func NewLaunchMonitor(logger *zap.Logger, config Shared.Config) *LaunchMonitor {
+	log := logger.With(zap.String("component", "LAUNCH_MONITOR"), zap.String("type", "REPLAY")).Sugar()
+
+	return &LaunchMonitor{
+		log:        log,
+		file:       config.Replay.File,
+		shotFile:   config.Bridge.ShotFile,
+		mode:       config.Replay.Mode,
+		data:       config.Replay.Data,
+		delay:      time.Duration(config.Replay.DelaySeconds * float64(time.Second)),
+		stepSignal: make(chan struct{}),
+		stopSignal: make(chan struct{}),
+	}
+}
+
+// Connect loads the shots to replay.
+// The shot file being written is refused, as every replayed shot would be appended to the history it replays.
+func (lm *LaunchMonitor) Connect() error {
+	if lm.file == "" {
+		return fmt.Errorf("a replay file is required")
+	}
+	if sameFile(lm.file, lm.shotFile) {
+		return fmt.Errorf("%s is the shot file replayed shots are saved to, replay a copy of it instead", lm.file)
+	}
+	switch lm.mode {
+	case ModeDelay, ModeOriginal, ModeManual:
+	default:
+		return fmt.Errorf("replay mode %s is not supported", lm.mode)
+	}
+	switch lm.data {
+	case DataRaw, DataAdjusted:
+	default:
+		return fmt.Errorf("replay data %s is not supported", lm.data)
+	}
+
+	lm.log.Infof("loading shots from %s...", lm.file)
+	shots, err := Storage.ReadShots(lm.file)
+	if err != nil {
+		return fmt.Errorf("loading shots: %w", err)
+	}
+	lm.shots = shots
+	lm.log.Infof("✅ loaded %d shots to replay in %s mode using %s data", len(shots), lm.mode, lm.data)
+	return nil
+}
+
+// Close stops the replay.
+func (lm *LaunchMonitor) Close() error {
+	lm.log.Infof("stopping replay...")
+	close(lm.stopSignal)
+	return nil
+}
+
+// Convention returns the replay data convention; shot files are written in the standard convention.
+func (lm *LaunchMonitor) Convention() Shared.Convention {
+	return Shared.StandardConvention
+}
+
+// SetOnShotCallback sets the callback function to be called when a shot is replayed.
+func (lm *LaunchMonitor) SetOnShotCallback(f Shared.ShotHandlerFunc) {
+	lm.onShot = f
+}
+
+// LaunchShot starts replaying the loaded shots.
+func (lm *LaunchMonitor) LaunchShot() {
+	go func() {
+		for i := range lm.shots {
+			if !lm.wait(i) {
+				lm.log.Infof("stopping replay loop.")
+				return
+			}
+			lm.fire(i)
+		}
+		lm.log.Infof("🏁 replay finished after %d shots", len(lm.shots))
+	}()
+}
+
+// Step replays the next shot when running in manual mode.
+func (lm *LaunchMonitor) Step() error {
+	if lm.mode != ModeManual {
+		return fmt.Errorf("replay is not in %s mode", ModeManual)
+	}
+	select {
+	case lm.stepSignal <- struct{}{}:
+		return nil
+	case <-time.After(time.Second):
+		return fmt.Errorf("replay is not waiting for a shot")
+	}
+}
+
+// Progress returns the number of shots replayed so far and the total number of shots.
+func (lm *LaunchMonitor) Progress() (int, int) {
+	lm.mutex.Lock()
+	defer lm.mutex.Unlock()
+	return lm.position, len(lm.shots)
+}
+
+// wait blocks until shot i should be replayed, returning false if the replay was stopped.
+func (lm *LaunchMonitor) wait(i int) bool {
+	var trigger <-chan time.Time
+	switch lm.mode {
+	case ModeManual:
+		lm.log.Infof("waiting for a step request to replay shot %d of %d", i+1, len(lm.shots))
+		select {
+		case <-lm.stepSignal:
+			return true
+		case <-lm.stopSignal:
+			return false
+		}
+	case ModeOriginal:
+		gap := time.Duration(0)
+		if i > 0 {
+			gap = lm.shots[i].Timestamp.Sub(lm.shots[i-1].Timestamp)
+		}
+		trigger = time.After(max(gap, 0))
+	default:
+		trigger = time.After(lm.delay)
+	}
+
+	select {
+	case <-trigger:
+		return true
+	case <-lm.stopSignal:
+		return false
+	}
+}
+
+// sameFile reports whether two paths name the same file.
+func sameFile(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
+}
+
+// fire replays shot i through the shot callback.
+// Adjusted data is marked so the modifiers and rules are not applied to it a second time.
+func (lm *LaunchMonitor) fire(i int) {
+	shot := lm.shots[i]
+	ballData, clubData := shot.Ball, shot.Club
+	if lm.data == DataAdjusted {
+		ballData, clubData = shot.AdjustedBall, shot.AdjustedClub
+	}
+
+	lm.log.Infof("⛳️ replaying shot %d of %d (%s, %s, recorded %s)", i+1, len(lm.shots), shot.ShotUUID, shot.ClubType, shot.Timestamp.Format(time.RFC3339))
+
+	lm.mutex.Lock()
+	lm.position = i + 1
+	lm.mutex.Unlock()
+
+	if lm.onShot != nil {
+		lm.onShot(ballData, clubData, Shared.ShotDataOptions{
+			ClubType:                  shot.ClubType,
+			ContainsBallData:          true,
+			ContainsClubData:          shot.Club != (Shared.StandardizedClubData{}),
+			LaunchMonitorBallDetected: true,
+			Adjusted:                  lm.data == DataAdjusted,
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package Serial
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const (
+	// FramingLine splits the stream on newlines, ignoring carriage returns.
+	FramingLine = "LINE"
+	// FramingLength reads a big-endian length prefix followed by that many bytes.
+	FramingLength = "LENGTH"
+
+	// maxFrameSize is the largest frame accepted from the device.
+	maxFrameSize = 64 * 1024
+)
+
+// Framer reads the next message from a serial stream.
+type Framer func(r *bufio.Reader) ([]byte, error)
+
+// NewFramer returns the framer for the given framing name.
+// lengthBytes is the size of the length prefix used by FramingLength (1, 2 or 4).
+func NewFramer(framing string, lengthBytes int) (Framer, error) {
+	switch framing {
+	case FramingLine:
+		return readLine, nil
+	case FramingLength:
+		switch lengthBytes {
+		case 1, 2, 4:
+		default:
+			return nil, fmt.Errorf("length prefix must be 1, 2 or 4 bytes, got %d", lengthBytes)
+		}
+		return func(r *bufio.Reader) ([]byte, error) {
+			return readLengthPrefixed(r, lengthBytes)
+		}, nil
+	default:
+		return nil, fmt.Errorf("framing %s is not supported", framing)
+	}
+}
+
+// readLine returns the next non-empty line.
+func readLine(r *bufio.Reader) ([]byte, error) {
+	for {
+		line, err := r.ReadBytes('\n')
+		if len(line) > maxFrameSize {
+			return nil, fmt.Errorf("line longer than %d bytes", maxFrameSize)
+		}
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 {
+			return line, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// readLengthPrefixed returns the payload of the next length-prefixed frame.
+func readLengthPrefixed(r *bufio.Reader, lengthBytes int) ([]byte, error) {
+	prefix := make([]byte, 4)
+	if _, err := io.ReadFull(r, prefix[4-lengthBytes:]); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(prefix)
+	if size > maxFrameSize {
+		return nil, fmt.Errorf("frame of %d bytes is longer than %d bytes", size, maxFrameSize)
+	}
+	frame := make([]byte, size)
+	if _, err := io.ReadFull(r, frame); err != nil {
+		return nil, err
+	}
+	return frame, nil
+}
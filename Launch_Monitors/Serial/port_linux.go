@@ -0,0 +1,80 @@
+//go:build linux
+
+package Serial
+
+import (
+	"fmt"
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// baudRates maps the supported baud rates to their termios constants.
+var baudRates = map[int]uint32{
+	1200:   unix.B1200,
+	2400:   unix.B2400,
+	4800:   unix.B4800,
+	9600:   unix.B9600,
+	19200:  unix.B19200,
+	38400:  unix.B38400,
+	57600:  unix.B57600,
+	115200: unix.B115200,
+	230400: unix.B230400,
+	460800: unix.B460800,
+	921600: unix.B921600,
+}
+
+// openPort opens a serial device in raw 8N1 mode at the given baud rate.
+func openPort(device string, baud int) (*os.File, error) {
+	rate, ok := baudRates[baud]
+	if !ok {
+		return nil, fmt.Errorf("baud rate %d is not supported", baud)
+	}
+
+	file, err := os.OpenFile(device, os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// The settings are applied through SyscallConn as Fd would put the file in blocking mode,
+	// and Close could then no longer interrupt a pending Read.
+	conn, err := file.SyscallConn()
+	if err != nil {
+		_ = file.Close()
+		return nil, err
+	}
+	var termiosErr error
+	if err := conn.Control(func(fd uintptr) {
+		termiosErr = setRaw(int(fd), rate)
+	}); err != nil {
+		termiosErr = err
+	}
+	if termiosErr != nil {
+		_ = file.Close()
+		return nil, termiosErr
+	}
+	return file, nil
+}
+
+// setRaw puts a terminal in raw 8N1 mode at the given baud rate.
+func setRaw(fd int, rate uint32) error {
+	termios, err := unix.IoctlGetTermios(fd, unix.TCGETS)
+	if err != nil {
+		return fmt.Errorf("reading terminal settings: %w", err)
+	}
+
+	termios.Iflag &^= unix.IGNBRK | unix.BRKINT | unix.PARMRK | unix.ISTRIP | unix.INLCR | unix.IGNCR | unix.ICRNL | unix.IXON
+	termios.Oflag &^= unix.OPOST
+	termios.Lflag &^= unix.ECHO | unix.ECHONL | unix.ICANON | unix.ISIG | unix.IEXTEN
+	termios.Cflag &^= unix.CSIZE | unix.PARENB | unix.CSTOPB | unix.CBAUD
+	termios.Cflag |= unix.CS8 | unix.CREAD | unix.CLOCAL | rate
+	termios.Ispeed = rate
+	termios.Ospeed = rate
+	termios.Cc[unix.VMIN] = 1
+	termios.Cc[unix.VTIME] = 0
+
+	if err := unix.IoctlSetTermios(fd, unix.TCSETS, termios); err != nil {
+		return fmt.Errorf("applying terminal settings: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+//go:build !linux
+
+package Serial
+
+import (
+	"fmt"
+	"os"
+	"runtime"
+)
+
+// openPort is only implemented on Linux.
+func openPort(device string, baud int) (*os.File, error) {
+	return nil, fmt.Errorf("serial launch monitors are not supported on %s", runtime.GOOS)
+}
@@ -0,0 +1,195 @@
+package Serial
+
+import (
+	"Fairway_Bridge/Launch_Monitors/Mapping"
+	"Fairway_Bridge/Shared"
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// reopenDelay is how long to wait before reopening a device that went away, e.g. an unplugged USB adapter.
+const reopenDelay = 2 * time.Second
+
+// LaunchMonitor reads shots from a serial device such as a USB radar or an Arduino trigger.
+// Frames are split by the configured framing and turned into shots by the mapping file.
+type LaunchMonitor struct {
+	device         string
+	baud           int
+	framing        string
+	lengthBytes    int
+	mappingFile    string
+	mapping        *Mapping.Mapping
+	framer         Framer
+	port           *os.File
+	portMutex      sync.Mutex
+	stopSignal     chan struct{}
+	log            *zap.SugaredLogger
+	onShotCallback Shared.ShotHandlerFunc
+}
+
+// NewLaunchMonitor creates a new instance of LaunchMonitor.
+func NewLaunchMonitor(logger *zap.Logger, config Shared.Config) *LaunchMonitor {
+	log := logger.With(zap.String("component", "LAUNCH_MONITOR"), zap.String("type", "SERIAL")).Sugar()
+	log.Info("initializing serial launch monitor")
+	return &LaunchMonitor{
+		device:      config.Serial.Device,
+		baud:        config.Serial.Baud,
+		framing:     config.Serial.Framing,
+		lengthBytes: config.Serial.LengthBytes,
+		mappingFile: config.Mapping.File,
+		stopSignal:  make(chan struct{}),
+		log:         log,
+	}
+}
+
+// Connect loads the mapping file, opens the serial device and starts reading frames.
+func (lm *LaunchMonitor) Connect() error {
+	if lm.device == "" {
+		return fmt.Errorf("a serial device is required")
+	}
+	if lm.mappingFile == "" {
+		return fmt.Errorf("a mapping file is required")
+	}
+	mapping, err := Mapping.Load(lm.mappingFile)
+	if err != nil {
+		return fmt.Errorf("loading mapping: %w", err)
+	}
+	lm.mapping = mapping
+	lm.log.Infof("✅ loaded %s mapping from %s", mapping.Format, lm.mappingFile)
+
+	framer, err := NewFramer(lm.framing, lm.lengthBytes)
+	if err != nil {
+		return err
+	}
+	lm.framer = framer
+
+	lm.log.Infof("opening %s at %d baud...", lm.device, lm.baud)
+	port, err := openPort(lm.device, lm.baud)
+	if err != nil {
+		return fmt.Errorf("opening %s: %w", lm.device, err)
+	}
+	lm.setPort(port)
+	lm.log.Infof("✅ reading %s frames from %s", lm.framing, lm.device)
+
+	go lm.readLoop(port)
+	return nil
+}
+
+// Close stops reading and closes the serial device.
+func (lm *LaunchMonitor) Close() error {
+	close(lm.stopSignal)
+	lm.portMutex.Lock()
+	defer lm.portMutex.Unlock()
+	if lm.port == nil {
+		return nil
+	}
+	err := lm.port.Close()
+	lm.port = nil
+	return err
+}
+
+// setPort records the open device so Close can release it.
+func (lm *LaunchMonitor) setPort(port *os.File) {
+	lm.portMutex.Lock()
+	defer lm.portMutex.Unlock()
+	lm.port = port
+}
+
+// stopped reports whether Close has been called.
+func (lm *LaunchMonitor) stopped() bool {
+	select {
+	case <-lm.stopSignal:
+		return true
+	default:
+		return false
+	}
+}
+
+// readLoop reads frames until the monitor is closed, reopening the device when it goes away.
+func (lm *LaunchMonitor) readLoop(port *os.File) {
+	for {
+		reader := bufio.NewReader(port)
+		for {
+			frame, err := lm.framer(reader)
+			if err != nil {
+				if !lm.stopped() && !errors.Is(err, os.ErrClosed) {
+					lm.log.Errorf("reading from %s: %v", lm.device, err)
+				}
+				break
+			}
+			lm.handleFrame(frame)
+		}
+		_ = port.Close()
+
+		port = lm.reopen()
+		if port == nil {
+			lm.log.Infof("stopping serial read loop.")
+			return
+		}
+	}
+}
+
+// reopen retries opening the device until it succeeds or the monitor is closed.
+func (lm *LaunchMonitor) reopen() *os.File {
+	for {
+		select {
+		case <-lm.stopSignal:
+			return nil
+		case <-time.After(reopenDelay):
+		}
+		port, err := openPort(lm.device, lm.baud)
+		if err != nil {
+			lm.log.Debugf("reopening %s: %v", lm.device, err)
+			continue
+		}
+		lm.setPort(port)
+		lm.log.Infof("✅ reopened %s", lm.device)
+		return port
+	}
+}
+
+// handleFrame maps a frame to a shot and passes it to the shot callback.
+func (lm *LaunchMonitor) handleFrame(frame []byte) {
+	msg, err := lm.mapping.Decode(frame)
+	if err != nil {
+		lm.log.Errorf("decoding frame: %v", err)
+		return
+	}
+
+	ballData, clubData, options, ok := lm.mapping.Apply(msg)
+	if !ok {
+		lm.log.Debugf("ignoring frame without a shot: %q", frame)
+		return
+	}
+	lm.log.Infof("⛳️ received shot (club=%s, speed=%.1f)", options.ClubType, ballData.Speed)
+
+	if lm.onShotCallback != nil {
+		lm.onShotCallback(ballData, clubData, options)
+	} else {
+		lm.log.Warnf("onShotCallback is nil, skipping callback invocation")
+	}
+}
+
+// SetOnShotCallback sets the callback function for shot events.
+func (lm *LaunchMonitor) SetOnShotCallback(callback Shared.ShotHandlerFunc) {
+	lm.onShotCallback = callback
+}
+
+// Convention returns the convention declared in the mapping file.
+func (lm *LaunchMonitor) Convention() Shared.Convention {
+	if lm.mapping == nil {
+		return Shared.StandardConvention
+	}
+	return lm.mapping.Convention()
+}
+
+// LaunchShot is a no-op as shots are triggered by the device.
+func (lm *LaunchMonitor) LaunchShot() {
+	lm.log.Infof("shots are triggered by the serial device")
+}
@@ -0,0 +1,105 @@
+//go:build linux
+
+package Serial
+
+import (
+	"Fairway_Bridge/Launch_Monitors/Mapping"
+	"Fairway_Bridge/Shared"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+	"golang.org/x/sys/unix"
+)
+
+// openPty opens a pseudo-terminal pair and returns its master and the path of its slave.
+func openPty(t *testing.T) (*os.File, string) {
+	t.Helper()
+	master, err := os.OpenFile("/dev/ptmx", os.O_RDWR|unix.O_NOCTTY, 0)
+	if err != nil {
+		t.Skipf("no pseudo-terminals: %v", err)
+	}
+	t.Cleanup(func() { _ = master.Close() })
+
+	conn, err := master.SyscallConn()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var n int
+	var ptyErr error
+	if err := conn.Control(func(fd uintptr) {
+		if ptyErr = unix.IoctlSetPointerInt(int(fd), unix.TIOCSPTLCK, 0); ptyErr != nil {
+			return
+		}
+		n, ptyErr = unix.IoctlGetInt(int(fd), unix.TIOCGPTN)
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if ptyErr != nil {
+		t.Skipf("no pseudo-terminals: %v", ptyErr)
+	}
+	return master, "/dev/pts/" + strconv.Itoa(n)
+}
+
+func TestReadLoopOverPty(t *testing.T) {
+	master, slave := openPty(t)
+
+	mappingFile := filepath.Join(t.TempDir(), "mapping.json")
+	if err := os.WriteFile(mappingFile, []byte(`{"ball": {"Speed": {"path": "speed", "unit": "mph"}}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	config := Shared.Config{}
+	config.Serial.Device = slave
+	config.Serial.Baud = 115200
+	config.Serial.Framing = FramingLine
+	config.Mapping.File = mappingFile
+	lm := NewLaunchMonitor(zap.NewNop(), config)
+
+	shots := make(chan Shared.StandardizedBallData, 1)
+	lm.SetOnShotCallback(func(ball Shared.StandardizedBallData, club Shared.StandardizedClubData, options Shared.ShotDataOptions) *Shared.ShotResult {
+		shots <- ball
+		return nil
+	})
+
+	// The read loop is started here rather than by Connect so the test can tell when it returns.
+	mapping, err := Mapping.Load(mappingFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lm.mapping = mapping
+	lm.framer = readLine
+	port, err := openPort(slave, lm.baud)
+	if err != nil {
+		t.Fatal(err)
+	}
+	lm.setPort(port)
+	done := make(chan struct{})
+	go func() {
+		lm.readLoop(port)
+		close(done)
+	}()
+
+	if _, err := master.Write([]byte(`{"speed": 150.5}` + "\n")); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case ball := <-shots:
+		if ball.Speed != 150.5 {
+			t.Errorf("speed is %v, want 150.5", ball.Speed)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("no shot received")
+	}
+
+	if err := lm.Close(); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("read loop still running after Close")
+	}
+}
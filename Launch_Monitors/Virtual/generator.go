@@ -0,0 +1,238 @@
+package Virtual
+
+import (
+	"Fairway_Bridge/Shared"
+	"fmt"
+	"math"
+	"math/rand/v2"
+	"strings"
+)
+
+// clubProfile holds the typical delivery of a club for a tour player.
+type clubProfile struct {
+	ClubSpeed     float64 // mph
+	Smash         float64
+	VLA           float64 // degrees
+	Spin          float64 // rpm
+	AngleOfAttack float64 // degrees
+	Loft          float64 // degrees
+	FaceWeight    float64 // share of the start direction set by the face rather than the path
+}
+
+// clubProfiles are based on published tour averages and interpolated for less common clubs.
+var clubProfiles = map[Shared.ClubType]clubProfile{
+	Shared.Driver:        {ClubSpeed: 113, Smash: 1.48, VLA: 10.9, Spin: 2686, AngleOfAttack: -1.3, Loft: 10.5, FaceWeight: 0.85},
+	Shared.ThreeWood:     {ClubSpeed: 107, Smash: 1.48, VLA: 9.2, Spin: 3655, AngleOfAttack: -2.9, Loft: 15, FaceWeight: 0.8},
+	Shared.FiveWood:      {ClubSpeed: 103, Smash: 1.47, VLA: 9.4, Spin: 4350, AngleOfAttack: -3.3, Loft: 18, FaceWeight: 0.8},
+	Shared.SevenWood:     {ClubSpeed: 101, Smash: 1.46, VLA: 10.5, Spin: 4600, AngleOfAttack: -3.3, Loft: 21, FaceWeight: 0.8},
+	Shared.TwoHybrid:     {ClubSpeed: 102, Smash: 1.47, VLA: 9.8, Spin: 4200, AngleOfAttack: -3.2, Loft: 17, FaceWeight: 0.78},
+	Shared.ThreeHybrid:   {ClubSpeed: 100, Smash: 1.46, VLA: 10.2, Spin: 4437, AngleOfAttack: -3.4, Loft: 19, FaceWeight: 0.78},
+	Shared.FourHybrid:    {ClubSpeed: 98, Smash: 1.45, VLA: 11.0, Spin: 4750, AngleOfAttack: -3.5, Loft: 22, FaceWeight: 0.77},
+	Shared.FiveHybrid:    {ClubSpeed: 96, Smash: 1.43, VLA: 12.0, Spin: 5100, AngleOfAttack: -3.6, Loft: 25, FaceWeight: 0.77},
+	Shared.SixHybrid:     {ClubSpeed: 94, Smash: 1.41, VLA: 13.5, Spin: 5600, AngleOfAttack: -3.8, Loft: 28, FaceWeight: 0.76},
+	Shared.OneIron:       {ClubSpeed: 101, Smash: 1.46, VLA: 9.5, Spin: 4300, AngleOfAttack: -2.9, Loft: 17, FaceWeight: 0.76},
+	Shared.TwoIron:       {ClubSpeed: 100, Smash: 1.46, VLA: 10.0, Spin: 4450, AngleOfAttack: -3.0, Loft: 19, FaceWeight: 0.76},
+	Shared.ThreeIron:     {ClubSpeed: 98, Smash: 1.45, VLA: 10.4, Spin: 4630, AngleOfAttack: -3.1, Loft: 21, FaceWeight: 0.75},
+	Shared.FourIron:      {ClubSpeed: 96, Smash: 1.43, VLA: 11.0, Spin: 4836, AngleOfAttack: -3.4, Loft: 23, FaceWeight: 0.75},
+	Shared.FiveIron:      {ClubSpeed: 94, Smash: 1.41, VLA: 12.1, Spin: 5361, AngleOfAttack: -3.7, Loft: 26, FaceWeight: 0.75},
+	Shared.SixIron:       {ClubSpeed: 92, Smash: 1.38, VLA: 14.1, Spin: 6231, AngleOfAttack: -4.1, Loft: 29, FaceWeight: 0.75},
+	Shared.SevenIron:     {ClubSpeed: 90, Smash: 1.33, VLA: 16.3, Spin: 7097, AngleOfAttack: -4.3, Loft: 33, FaceWeight: 0.75},
+	Shared.EightIron:     {ClubSpeed: 87, Smash: 1.32, VLA: 18.1, Spin: 7998, AngleOfAttack: -4.5, Loft: 37, FaceWeight: 0.75},
+	Shared.NineIron:      {ClubSpeed: 85, Smash: 1.28, VLA: 20.4, Spin: 8647, AngleOfAttack: -4.7, Loft: 41, FaceWeight: 0.75},
+	Shared.PitchingWedge: {ClubSpeed: 83, Smash: 1.23, VLA: 24.2, Spin: 9304, AngleOfAttack: -5.0, Loft: 46, FaceWeight: 0.75},
+	Shared.GapWedge:      {ClubSpeed: 81, Smash: 1.20, VLA: 27.0, Spin: 9800, AngleOfAttack: -5.0, Loft: 50, FaceWeight: 0.75},
+	Shared.SandWedge:     {ClubSpeed: 78, Smash: 1.18, VLA: 30.0, Spin: 10200, AngleOfAttack: -5.0, Loft: 56, FaceWeight: 0.75},
+	Shared.LobWedge:      {ClubSpeed: 75, Smash: 1.15, VLA: 33.0, Spin: 10500, AngleOfAttack: -5.0, Loft: 60, FaceWeight: 0.75},
+	Shared.Putter:        {ClubSpeed: 6, Smash: 1.4, VLA: 2.0, Spin: 150, AngleOfAttack: 2.0, Loft: 3, FaceWeight: 0.9},
+}
+
+// SkillLevel scales the speed and consistency of generated shots.
+type SkillLevel struct {
+	Name        string
+	SpeedFactor float64 // share of tour club speed
+	SpeedStd    float64 // relative club speed variation
+	FaceStd     float64 // degrees
+	PathStd     float64 // degrees
+	ImpactStd   float64 // mm from the centre of the face
+	LaunchStd   float64 // degrees
+}
+
+// SkillLevels are the available skill presets.
+var SkillLevels = map[string]SkillLevel{
+	"TOUR":     {Name: "TOUR", SpeedFactor: 1.0, SpeedStd: 0.015, FaceStd: 1.2, PathStd: 1.5, ImpactStd: 5, LaunchStd: 0.8},
+	"SCRATCH":  {Name: "SCRATCH", SpeedFactor: 0.93, SpeedStd: 0.025, FaceStd: 1.8, PathStd: 2.2, ImpactStd: 8, LaunchStd: 1.2},
+	"AVERAGE":  {Name: "AVERAGE", SpeedFactor: 0.82, SpeedStd: 0.04, FaceStd: 3.0, PathStd: 3.5, ImpactStd: 12, LaunchStd: 2.0},
+	"BEGINNER": {Name: "BEGINNER", SpeedFactor: 0.7, SpeedStd: 0.06, FaceStd: 4.5, PathStd: 5.0, ImpactStd: 18, LaunchStd: 3.0},
+}
+
+// Tendency biases the face and path of generated shots, for a right-handed golfer.
+type Tendency struct {
+	Name     string
+	FaceBias float64 // degrees, positive is open
+	PathBias float64 // degrees, positive is in-to-out
+}
+
+// Tendencies are the available shot shape tendencies.
+var Tendencies = map[string]Tendency{
+	"STRAIGHT": {Name: "STRAIGHT"},
+	"HOOK":     {Name: "HOOK", FaceBias: 0.5, PathBias: 4},
+	"SLICE":    {Name: "SLICE", FaceBias: 1, PathBias: -4},
+	"PUSH":     {Name: "PUSH", FaceBias: 3, PathBias: 3},
+	"PULL":     {Name: "PULL", FaceBias: -3, PathBias: -3},
+}
+
+// Generator produces realistic shots for a club, skill level and tendency.
+type Generator struct {
+	skill    SkillLevel
+	tendency Tendency
+}
+
+// NewGenerator creates a shot generator for the named skill level and tendency.
+func NewGenerator(skill string, tendency string) (*Generator, error) {
+	s, ok := SkillLevels[strings.ToUpper(skill)]
+	if !ok {
+		return nil, fmt.Errorf("skill level %s is not supported", skill)
+	}
+	t, ok := Tendencies[strings.ToUpper(tendency)]
+	if !ok {
+		return nil, fmt.Errorf("tendency %s is not supported", tendency)
+	}
+	return &Generator{skill: s, tendency: t}, nil
+}
+
+// Shot generates ball and club data for a swing with the given club.
+func (g *Generator) Shot(clubType Shared.ClubType) (Shared.StandardizedBallData, Shared.StandardizedClubData) {
+	profile, ok := clubProfiles[clubType]
+	if !ok {
+		profile = clubProfiles[Shared.SevenIron]
+	}
+	if clubType == Shared.Putter {
+		return g.putt(profile)
+	}
+
+	// Face and path are drawn independently around the tendency; the start direction follows mostly
+	// the face and the curvature follows the face-to-path difference.
+	face := g.tendency.FaceBias + rand.NormFloat64()*g.skill.FaceStd
+	path := g.tendency.PathBias + rand.NormFloat64()*g.skill.PathStd
+	hla := profile.FaceWeight*face + (1-profile.FaceWeight)*path
+	spinAxis := math.Max(-45, math.Min(45, (face-path)*2.5))
+
+	// Off-centre strikes cost ball speed; high strikes launch higher with less spin.
+	horizontalImpact := rand.NormFloat64() * g.skill.ImpactStd
+	verticalImpact := rand.NormFloat64() * g.skill.ImpactStd
+	smash := profile.Smash - 0.004*math.Abs(horizontalImpact) - 0.002*math.Abs(verticalImpact)
+
+	clubSpeed := profile.ClubSpeed * g.skill.SpeedFactor * (1 + rand.NormFloat64()*g.skill.SpeedStd)
+	ballSpeed := clubSpeed * smash
+	vla := profile.VLA + 0.1*verticalImpact + rand.NormFloat64()*g.skill.LaunchStd
+	totalSpin := profile.Spin * (0.85 + 0.15*g.skill.SpeedFactor) * (1 + rand.NormFloat64()*0.06)
+	totalSpin = math.Max(500, totalSpin-40*verticalImpact)
+
+	// Tendencies describe the golfer's own shape, so they are mirrored for left-handed golfers.
+	if Shared.GetHandedness() == Shared.LeftHanded {
+		face, path, hla, spinAxis = -face, -path, -hla, -spinAxis
+		horizontalImpact = -horizontalImpact
+	}
+
+	ballData := Shared.StandardizedBallData{
+		Speed:     ballSpeed,
+		SpinAxis:  spinAxis,
+		TotalSpin: totalSpin,
+		BackSpin:  totalSpin * math.Cos(spinAxis*math.Pi/180),
+		SideSpin:  totalSpin * math.Sin(spinAxis*math.Pi/180),
+		HLA:       hla,
+		VLA:       vla,
+	}
+	clubData := Shared.StandardizedClubData{
+		Speed:                clubSpeed,
+		AngleOfAttack:        profile.AngleOfAttack + rand.NormFloat64()*g.skill.LaunchStd,
+		FaceToTarget:         face,
+		Lie:                  rand.NormFloat64() * g.skill.LaunchStd,
+		Loft:                 profile.Loft + rand.NormFloat64()*g.skill.LaunchStd,
+		Path:                 path,
+		SpeedAtImpact:        clubSpeed,
+		VerticalFaceImpact:   verticalImpact,
+		HorizontalFaceImpact: horizontalImpact,
+	}
+	return ballData, clubData
+}
+
+// putt generates a putt with a typical green speed and a small start line error.
+func (g *Generator) putt(profile clubProfile) (Shared.StandardizedBallData, Shared.StandardizedClubData) {
+	clubSpeed := math.Max(1, profile.ClubSpeed*(1+rand.NormFloat64()*0.3))
+	face := rand.NormFloat64() * g.skill.FaceStd / 3
+	ballData := Shared.StandardizedBallData{
+		Speed:     clubSpeed * profile.Smash,
+		TotalSpin: profile.Spin,
+		BackSpin:  profile.Spin,
+		HLA:       face,
+		VLA:       profile.VLA,
+	}
+	clubData := Shared.StandardizedClubData{
+		Speed:         clubSpeed,
+		AngleOfAttack: profile.AngleOfAttack,
+		FaceToTarget:  face,
+		Loft:          profile.Loft,
+		SpeedAtImpact: clubSpeed,
+	}
+	return ballData, clubData
+}
+
+// ClubForDistance returns the club whose typical carry is closest to the target distance in yards.
+func (g *Generator) ClubForDistance(distance float64) Shared.ClubType {
+	best := Shared.SevenIron
+	bestGap := math.Inf(1)
+	for _, clubType := range Shared.ClubTypes {
+		if clubType == Shared.Putter {
+			continue
+		}
+		gap := math.Abs(g.typicalCarry(clubType) - distance)
+		if gap < bestGap {
+			best, bestGap = clubType, gap
+		}
+	}
+	return best
+}
+
+// typicalCarry returns the carry of a centred, straight shot with the club at this skill level.
+func (g *Generator) typicalCarry(clubType Shared.ClubType) float64 {
+	profile := clubProfiles[clubType]
+	speed := profile.ClubSpeed * g.skill.SpeedFactor * profile.Smash
+	spin := profile.Spin * (0.85 + 0.15*g.skill.SpeedFactor)
+	return Shared.EstimateFlight(Shared.StandardizedBallData{Speed: speed, TotalSpin: spin, VLA: profile.VLA}, clubType).CarryDistance
+}
+
+// ShotForDistance generates a shot with the club whose speed is scaled so that it carries roughly the target distance.
+// When no club is given, the club is chosen from the distance.
+func (g *Generator) ShotForDistance(clubType Shared.ClubType, distance float64) (Shared.StandardizedBallData, Shared.StandardizedClubData, Shared.ClubType) {
+	if clubType == "" {
+		clubType = g.ClubForDistance(distance)
+	}
+	ballData, clubData := g.Shot(clubType)
+	if clubType == Shared.Putter || distance <= 0 {
+		return ballData, clubData, clubType
+	}
+
+	// Bisect the speed scale until the modelled carry matches the target.
+	low, high := 0.3, 2.0
+	for i := 0; i < 25; i++ {
+		scale := (low + high) / 2
+		scaled := ballData
+		scaled.Speed = ballData.Speed * scale
+		if Shared.EstimateFlight(scaled, clubType).CarryDistance < distance {
+			low = scale
+		} else {
+			high = scale
+		}
+	}
+	scale := (low + high) / 2
+	ballData.Speed *= scale
+	clubData.Speed *= scale
+	clubData.SpeedAtImpact *= scale
+	return ballData, clubData, clubType
+}
+
+// RandomClub returns a random full-swing club.
+func RandomClub() Shared.ClubType {
+	return Shared.ClubTypes[rand.IntN(len(Shared.ClubTypes)-1)]
+}
@@ -0,0 +1,126 @@
+package Virtual
+
+import (
+	"Fairway_Bridge/Shared"
+	"fmt"
+	"math/rand/v2"
+)
+
+// Scenario is a scripted sequence of virtual shots loaded from a YAML or JSON file.
+type Scenario struct {
+	Loop  bool           `json:"loop"`
+	Steps []ScenarioStep `json:"steps"`
+}
+
+// ScenarioStep describes one or more shots fired by a scenario.
+// A step gives explicit ball and club values, a target distance, or just a club to generate a typical shot.
+type ScenarioStep struct {
+	Club         string                       `json:"club"`
+	Distance     float64                      `json:"distance"`
+	Ball         *Shared.StandardizedBallData `json:"ball"`
+	ClubData     *Shared.StandardizedClubData `json:"club_data"`
+	DelaySeconds float64                      `json:"delay_seconds"`
+	Repeat       int                          `json:"repeat"`
+	Jitter       *ScenarioJitter              `json:"jitter"`
+}
+
+// ScenarioJitter gives the maximum random deviation, in either direction, applied to each field.
+type ScenarioJitter struct {
+	Ball Shared.StandardizedBallData `json:"ball"`
+	Club Shared.StandardizedClubData `json:"club"`
+}
+
+// LoadScenario reads a scenario from a YAML or JSON file.
+func LoadScenario(path string) (Scenario, error) {
+	var scenario Scenario
+	if err := Shared.ReadConfigFile(path, &scenario); err != nil {
+		return scenario, err
+	}
+
+	if len(scenario.Steps) == 0 {
+		return scenario, fmt.Errorf("scenario %s has no steps", path)
+	}
+	waits := false
+	for i, step := range scenario.Steps {
+		if step.Ball == nil && step.Distance <= 0 && step.Club == "" {
+			return scenario, fmt.Errorf("scenario step %d needs a club, a distance or ball data", i+1)
+		}
+		if step.Club != "" && !Shared.ValidClubTypes[Shared.ClubType(step.Club)] {
+			return scenario, fmt.Errorf("scenario step %d has unknown club %s", i+1, step.Club)
+		}
+		if step.DelaySeconds < 0 {
+			return scenario, fmt.Errorf("scenario step %d has a negative delay", i+1)
+		}
+		waits = waits || step.DelaySeconds > 0
+	}
+	// A loop that never waits would fire shots as fast as they can be processed.
+	if scenario.Loop && !waits {
+		return scenario, fmt.Errorf("scenario %s loops without a delay_seconds in any step", path)
+	}
+	return scenario, nil
+}
+
+// shot returns the ball data, club data and club type for one shot of the step.
+func (step ScenarioStep) shot(generator *Generator) (Shared.StandardizedBallData, Shared.StandardizedClubData, Shared.ClubType) {
+	var ballData Shared.StandardizedBallData
+	var clubData Shared.StandardizedClubData
+	clubType := Shared.ClubType(step.Club)
+	switch {
+	case step.Ball != nil:
+		ballData = *step.Ball
+		if step.ClubData != nil {
+			clubData = *step.ClubData
+		}
+		if clubType == "" {
+			clubType = generator.ClubForDistance(Shared.EstimateFlight(ballData, "").CarryDistance)
+		}
+	case step.Distance > 0:
+		ballData, clubData, clubType = generator.ShotForDistance(clubType, step.Distance)
+	default:
+		ballData, clubData = generator.Shot(clubType)
+	}
+
+	if step.Jitter != nil {
+		ballData = jitterBall(ballData, step.Jitter.Ball)
+		clubData = jitterClub(clubData, step.Jitter.Club)
+	}
+	return ballData, clubData, clubType
+}
+
+// jitter returns value moved by a uniformly random amount within ±spread.
+func jitter(value float64, spread float64) float64 {
+	if spread == 0 {
+		return value
+	}
+	return value + (rand.Float64()*2-1)*spread
+}
+
+// jitterBall applies random jitter to every ball field.
+func jitterBall(ball Shared.StandardizedBallData, spread Shared.StandardizedBallData) Shared.StandardizedBallData {
+	return Shared.StandardizedBallData{
+		Speed:         jitter(ball.Speed, spread.Speed),
+		SpinAxis:      jitter(ball.SpinAxis, spread.SpinAxis),
+		TotalSpin:     jitter(ball.TotalSpin, spread.TotalSpin),
+		BackSpin:      jitter(ball.BackSpin, spread.BackSpin),
+		SideSpin:      jitter(ball.SideSpin, spread.SideSpin),
+		HLA:           jitter(ball.HLA, spread.HLA),
+		VLA:           jitter(ball.VLA, spread.VLA),
+		CarryDistance: jitter(ball.CarryDistance, spread.CarryDistance),
+	}
+}
+
+// jitterClub applies random jitter to every club field.
+func jitterClub(club Shared.StandardizedClubData, spread Shared.StandardizedClubData) Shared.StandardizedClubData {
+	return Shared.StandardizedClubData{
+		Speed:                jitter(club.Speed, spread.Speed),
+		AngleOfAttack:        jitter(club.AngleOfAttack, spread.AngleOfAttack),
+		FaceToTarget:         jitter(club.FaceToTarget, spread.FaceToTarget),
+		Lie:                  jitter(club.Lie, spread.Lie),
+		Loft:                 jitter(club.Loft, spread.Loft),
+		Path:                 jitter(club.Path, spread.Path),
+		SpeedAtImpact:        jitter(club.SpeedAtImpact, spread.SpeedAtImpact),
+		VerticalFaceImpact:   jitter(club.VerticalFaceImpact, spread.VerticalFaceImpact),
+		HorizontalFaceImpact: jitter(club.HorizontalFaceImpact, spread.HorizontalFaceImpact),
+		ClosureRate:          jitter(club.ClosureRate, spread.ClosureRate),
+	}
+}
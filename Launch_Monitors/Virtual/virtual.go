@@ -6,32 +6,58 @@ import (
 	"fmt"
 	"go.uber.org/zap"
 	"math"
-	"math/rand/v2"
 	"os"
 	"strconv"
+	"strings"
+	"sync"
 	"time"
 )
 
 // LaunchMonitor simulates a launch monitor.
 type LaunchMonitor struct {
-	log        *zap.SugaredLogger
-	onShot     Shared.ShotHandlerFunc
-	stopSignal chan struct{}
+	log          *zap.SugaredLogger
+	onShot       Shared.ShotHandlerFunc
+	scenarioFile string
+	scenario     Scenario
+	skill        string
+	tendency     string
+	generator    *Generator
+	prompt       bool
+	units        Shared.UnitSystem
+	shotMutex    sync.Mutex
+	stopSignal   chan struct{}
 }
 
 // NewLaunchMonitor initializes a virtual launch monitor.
-func NewLaunchMonitor(logger *zap.Logger) *LaunchMonitor {
+func NewLaunchMonitor(logger *zap.Logger, config Shared.Config) *LaunchMonitor {
 	log := logger.With(zap.String("component", "LAUNCH_MONITOR"), zap.String("type", "VIRTUAL")).Sugar()
 
 	return &LaunchMonitor{
-		log:        log,
-		stopSignal: make(chan struct{}),
+		log:          log,
+		scenarioFile: config.Virtual.Scenario,
+		skill:        config.Virtual.Skill,
+		tendency:     config.Virtual.Tendency,
+		prompt:       config.Virtual.Prompt,
+		units:        config.Display.Units,
+		stopSignal:   make(chan struct{}),
 	}
 }
 
 // Connect simulates connecting to a launch monitor.
 func (lm *LaunchMonitor) Connect() error {
 	lm.log.Infof("connecting to virtual launch monitor...")
+	generator, err := NewGenerator(lm.skill, lm.tendency)
+	if err != nil {
+		return err
+	}
+	lm.generator = generator
+	if lm.scenarioFile != "" {
+		scenario, err := LoadScenario(lm.scenarioFile)
+		if err != nil {
+			return fmt.Errorf("loading scenario: %w", err)
+		}
+		lm.scenario = scenario
+	}
 	time.Sleep(1 * time.Second) // Simulate connection delay
 	lm.log.Infof("✅ virtual launch monitor is ready!")
 	return nil
@@ -51,136 +77,133 @@ func (lm *LaunchMonitor) SetOnShotCallback(f Shared.ShotHandlerFunc) {
 	lm.onShot = f
 }
 
-// RandomShot simulates launching a shot and calls the onShot callback.
+// Convention returns the virtual launch monitor's native data convention.
+func (lm *LaunchMonitor) Convention() Shared.Convention {
+	return Shared.StandardConvention
+}
+
+// RandomShot simulates launching a shot with a random club and calls the onShot callback.
 func (lm *LaunchMonitor) RandomShot() {
 	lm.log.Infof("🏌️ simulating shot launch...")
 	time.Sleep(1 * time.Second) // Simulate processing time
 
-	// Generate random shot data
-	ballData := Shared.StandardizedBallData{
-		Speed:         rand.Float64()*50 + 120,
-		SpinAxis:      rand.Float64()*10 - 5,
-		TotalSpin:     rand.Float64()*4000 + 2000,
-		HLA:           rand.Float64()*5 - 2.5,
-		VLA:           rand.Float64()*10 + 10,
-		CarryDistance: rand.Float64()*100 + 150,
-	}
+	clubType := RandomClub()
+	ballData, clubData := lm.generator.Shot(clubType)
+	lm.fire(ballData, clubData, clubType)
+}
 
-	clubData := Shared.StandardizedClubData{
-		Speed:                rand.Float64()*20 + 90,
-		AngleOfAttack:        rand.Float64()*5 - 2.5,
-		FaceToTarget:         rand.Float64()*5 - 2.5,
-		Lie:                  rand.Float64()*5 - 2.5,
-		Loft:                 rand.Float64()*5 + 8,
-		Path:                 rand.Float64()*5 - 2.5,
-		SpeedAtImpact:        rand.Float64()*20 + 90,
-		VerticalFaceImpact:   rand.Float64()*2 - 1,
-		HorizontalFaceImpact: rand.Float64()*2 - 1,
-		ClosureRate:          rand.Float64()*5 - 2.5,
+// promptInput prompts the user for input and returns the value as a float64.
+// It returns an error when standard input is closed.
+func promptInput(reader *bufio.Reader, prompt string, defaultValue float64) (float64, error) {
+	fmt.Printf("%s (default: %.1f): ", prompt, defaultValue)
+	input, err := reader.ReadString('\n')
+	input = strings.TrimSpace(input)
+	if err != nil && input == "" {
+		return 0, err
+	}
+	if input == "" {
+		return defaultValue, nil
+	}
+	value, err := strconv.ParseFloat(input, 64)
+	if err != nil {
+		fmt.Println("Invalid input, using default value.")
+		return defaultValue, nil
 	}
+	return value, nil
+}
+
+// fire logs the shot and calls the onShot callback, returning the simulator's result.
+// Shots are fired one at a time, whichever input triggered them.
+func (lm *LaunchMonitor) fire(ballData Shared.StandardizedBallData, clubData Shared.StandardizedClubData, clubType Shared.ClubType) *Shared.ShotResult {
+	lm.shotMutex.Lock()
+	defer lm.shotMutex.Unlock()
 
 	lm.log.Infof("⛳️ shot launched! %v %v", ballData, clubData)
 
 	// Call the callback function
 	if lm.onShot != nil {
-		lm.onShot(ballData, clubData, Shared.ShotDataOptions{
-			ClubType:         "Driver",
+		return lm.onShot(ballData, clubData, Shared.ShotDataOptions{
+			ClubType:         string(clubType),
 			ContainsClubData: true,
 			ContainsBallData: true,
 		})
 	}
+	return nil
 }
 
-// promptInput prompts the user for input and returns the value as a float64.
-func promptInput(prompt string, defaultValue float64) float64 {
-	reader := bufio.NewReader(os.Stdin)
-	fmt.Printf("%s (default: %.1f): ", prompt, defaultValue)
-	input, _ := reader.ReadString('\n')
-	input = input[:len(input)-1]
-	if input == "" {
-		return defaultValue
-	}
-	value, err := strconv.ParseFloat(input, 64)
-	if err != nil {
-		fmt.Println("Invalid input, using default value.")
-		return defaultValue
-	}
-	return value
+// TriggerShot fires a shot with the given ball and club data.
+func (lm *LaunchMonitor) TriggerShot(ballData Shared.StandardizedBallData, clubData Shared.StandardizedClubData, clubType Shared.ClubType) *Shared.ShotResult {
+	lm.log.Infof("🏌️ simulating requested shot launch...")
+	return lm.fire(ballData, clubData, clubType)
+}
+
+// TriggerDistanceShot fires a generated shot that carries roughly the given distance.
+// When no club is given, the club is chosen from the distance.
+func (lm *LaunchMonitor) TriggerDistanceShot(clubType Shared.ClubType, distance float64) (Shared.StandardizedBallData, Shared.StandardizedClubData, Shared.ClubType, *Shared.ShotResult) {
+	lm.log.Infof("🏌️ simulating requested %.1f yard shot launch...", distance)
+	ballData, clubData, clubType := lm.generator.ShotForDistance(clubType, distance)
+	return ballData, clubData, clubType, lm.fire(ballData, clubData, clubType)
 }
 
-// LaunchShot simulates launching a shot with a specified distance and fixed launch angle.
+// LaunchShot starts the scenario if one is configured, otherwise it prompts for target distances.
+// Without a scenario or prompt, shots are only fired through TriggerShot and TriggerDistanceShot.
 func (lm *LaunchMonitor) LaunchShot() {
+	if lm.scenarioFile != "" {
+		go lm.runScenario()
+		return
+	}
+	if !lm.prompt {
+		lm.log.Infof("prompt disabled, waiting for shot requests")
+		return
+	}
+
 	go func() {
+		reader := bufio.NewReader(os.Stdin)
 		for {
 			select {
 			case <-lm.stopSignal:
 				lm.log.Infof("stopping prompt input loop.")
 				return
 			default:
-				distance := promptInput("Enter target distance (yards)", 150.0)
-
-				lm.log.Infof("🏌️ simulating shot launch...")
-
-				// Fixed launch angle (in degrees)
-				launchAngle := 25.0
-				theta := launchAngle * (math.Pi / 180.0)
-
-				// Convert distance from yards to meters (1 yard = 0.9144 m)
-				distanceMeters := distance * 0.9144
-				g := 9.81 // gravitational acceleration (m/s²)
-
-				// Ideal projectile motion formula: distance = (v² * sin(2θ)) / g
-				// Solve for v (m/s): v = sqrt(distanceMeters * g / sin(2θ))
-				vMPS := math.Sqrt(distanceMeters * g / math.Sin(2*theta))
-
-				// Convert velocity from m/s to mph (1 m/s ≈ 2.23694 mph or divide by 0.44704)
-				computedBallSpeed := vMPS / 0.44704
-
-				// Correction factor to adjust for simulator physics (tweak this value as needed)
-				correctionFactor := 1.2 // Lowered from 1.5 to reduce overshooting
-				ballSpeed := computedBallSpeed * correctionFactor
-
-				// Calculate club head speed as a fraction of ball speed
-				clubHeadSpeed := ballSpeed * 0.95
-
-				// Clamp club head speed to maximum allowed value (200 mph)
-				if clubHeadSpeed > 200 {
-					clubHeadSpeed = 200
+				defaultDistance := Shared.ConvertDistance(150.0, Shared.StandardUnits, lm.units)
+				distance, err := promptInput(reader, fmt.Sprintf("Enter target distance (%s)", lm.units.DistanceUnit()), math.Round(defaultDistance))
+				if err != nil {
+					lm.log.Warnf("stopping prompt input loop, standard input is unavailable: %v", err)
+					return
 				}
+				distance = Shared.ConvertDistance(distance, lm.units, Shared.StandardUnits)
 
-				// Other default shot parameters
-				spinAxis := 3.0        // Draw shot
-				totalSpin := 4000.0    // Total spin in RPM
-				launchDirection := 0.0 // No horizontal deviation
-				clubAngleFace := 0.0   // Club face square
-				clubAnglePath := 0.0   // Neutral club path
-
-				// Generate random shot data
-				ballData := Shared.StandardizedBallData{
-					Speed:     ballSpeed,
-					SpinAxis:  spinAxis,
-					TotalSpin: totalSpin,
-					HLA:       launchDirection,
-					VLA:       launchAngle,
-				}
+				lm.log.Infof("🏌️ simulating shot launch...")
+				ballData, clubData, clubType := lm.generator.ShotForDistance("", distance)
+				lm.fire(ballData, clubData, clubType)
+			}
+		}
+	}()
+}
 
-				clubData := Shared.StandardizedClubData{
-					Speed:         clubHeadSpeed,
-					AngleOfAttack: clubAnglePath,
-					FaceToTarget:  clubAngleFace,
+// runScenario fires the shots of the scenario loaded by Connect.
+func (lm *LaunchMonitor) runScenario() {
+	scenario := lm.scenario
+	lm.log.Infof("running scenario %s with %d steps (loop=%t)", lm.scenarioFile, len(scenario.Steps), scenario.Loop)
+
+	for {
+		for i, step := range scenario.Steps {
+			for n := 0; n < max(step.Repeat, 1); n++ {
+				select {
+				case <-lm.stopSignal:
+					lm.log.Infof("stopping scenario.")
+					return
+				case <-time.After(time.Duration(step.DelaySeconds * float64(time.Second))):
 				}
 
-				lm.log.Infof("⛳️ shot launched! %v %v", ballData, clubData)
-
-				// Call the callback function
-				if lm.onShot != nil {
-					lm.onShot(ballData, clubData, Shared.ShotDataOptions{
-						ClubType:         "7Iron",
-						ContainsClubData: true,
-						ContainsBallData: true,
-					})
-				}
+				lm.log.Infof("🏌️ scenario step %d/%d (shot %d of %d)", i+1, len(scenario.Steps), n+1, max(step.Repeat, 1))
+				ballData, clubData, clubType := step.shot(lm.generator)
+				lm.fire(ballData, clubData, clubType)
 			}
 		}
-	}()
+		if !scenario.Loop {
+			lm.log.Infof("🏁 scenario finished")
+			return
+		}
+	}
 }
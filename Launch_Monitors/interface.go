@@ -10,4 +10,55 @@ type LaunchMonitorController interface {
 	Close() error
 	LaunchShot()
 	SetOnShotCallback(f Shared.ShotHandlerFunc)
+	Convention() Shared.Convention
+}
+
+// Stepper is implemented by launch monitors that emit a shot each time they are asked to.
+type Stepper interface {
+	Step() error
+	Progress() (int, int)
+}
+
+// ShotTrigger is implemented by launch monitors that can fire shots on request.
+type ShotTrigger interface {
+	TriggerShot(ballData Shared.StandardizedBallData, clubData Shared.StandardizedClubData, clubType Shared.ClubType) *Shared.ShotResult
+	TriggerDistanceShot(clubType Shared.ClubType, distance float64) (Shared.StandardizedBallData, Shared.StandardizedClubData, Shared.ClubType, *Shared.ShotResult)
+}
+
+// ResponseRelay is implemented by launch monitors that forward simulator responses back to the device.
+type ResponseRelay interface {
+	RelayResponse(response Shared.SimulatorResponse)
+}
+
+// ClubSetter is implemented by launch monitors that follow the club selected in the simulator.
+type ClubSetter interface {
+	SetClubType(clubType Shared.ClubType)
+}
+
+// PuttingController is implemented by launch monitors that accept putts and switch between full swing and putting.
+type PuttingController interface {
+	TriggerPutt(speed float64, hla float64) *Shared.ShotResult
+	SetPuttingMode(active bool)
+	PuttingMode() bool
+}
+
+// Composite is implemented by launch monitors that combine several other launch monitors.
+type Composite interface {
+	LaunchMonitors() []LaunchMonitorController
+}
+
+// Find returns the launch monitor, or the first launch monitor inside a composite, that implements T.
+func Find[T any](lm LaunchMonitorController) (T, bool) {
+	if found, ok := lm.(T); ok {
+		return found, true
+	}
+	if composite, ok := lm.(Composite); ok {
+		for _, member := range composite.LaunchMonitors() {
+			if found, ok := Find[T](member); ok {
+				return found, true
+			}
+		}
+	}
+	var zero T
+	return zero, false
 }
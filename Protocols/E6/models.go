@@ -0,0 +1,66 @@
+package E6
+
+import "Fairway_Bridge/Shared"
+
+// BallData holds ball flight measurements.
+type BallData struct {
+	BallSpeed       float64 `json:"BallSpeed"`
+	SpinAxis        float64 `json:"SpinAxis"`
+	TotalSpin       float64 `json:"TotalSpin"`
+	LaunchDirection float64 `json:"LaunchDirection"`
+	LaunchAngle     float64 `json:"LaunchAngle"`
+}
+
+// ClubData holds club-related measurements.
+type ClubData struct {
+	ClubHeadSpeed float64 `json:"ClubHeadSpeed"`
+	ClubAngleFace float64 `json:"ClubAngleFace"`
+	ClubAnglePath float64 `json:"ClubAnglePath"`
+}
+
+// Convention is the data convention of the E6 protocol, spoken by the R10 and by E6 Connect.
+// Spin axis is reported from 0° to 360° with the opposite sign to the standard convention.
+var Convention = Shared.Convention{
+	SpinAxisRange:     Shared.SpinAxisUnsigned,
+	SpinAxisDirection: Shared.LeftPositive,
+	HLADirection:      Shared.RightPositive,
+	Framing:           Shared.TargetFraming,
+}
+
+// ConvertToStandard converts E6 BallData and ClubData into their standardized equivalents.
+func ConvertToStandard(ball BallData, club ClubData) (Shared.StandardizedBallData, Shared.StandardizedClubData) {
+	standardBall := Shared.StandardizedBallData{
+		Speed:     ball.BallSpeed,
+		SpinAxis:  ball.SpinAxis,
+		TotalSpin: ball.TotalSpin,
+		HLA:       ball.LaunchDirection,
+		VLA:       ball.LaunchAngle,
+	}
+
+	standardClub := Shared.StandardizedClubData{
+		Speed:        club.ClubHeadSpeed,
+		FaceToTarget: club.ClubAngleFace,
+		Path:         club.ClubAnglePath,
+	}
+
+	return standardBall, standardClub
+}
+
+// ConvertToE6 converts the StandardizedBallData and StandardizedClubData to the E6 types.
+func ConvertToE6(ball Shared.StandardizedBallData, club Shared.StandardizedClubData) (BallData, ClubData) {
+	e6Ball := BallData{
+		BallSpeed:       ball.Speed,
+		SpinAxis:        ball.SpinAxis,
+		TotalSpin:       ball.TotalSpin,
+		LaunchDirection: ball.HLA,
+		LaunchAngle:     ball.VLA,
+	}
+
+	e6Club := ClubData{
+		ClubHeadSpeed: club.Speed,
+		ClubAngleFace: club.FaceToTarget,
+		ClubAnglePath: club.Path,
+	}
+
+	return e6Ball, e6Club
+}
@@ -0,0 +1,134 @@
+package Router
+
+import (
+	"Fairway_Bridge/Shared"
+	"fmt"
+	"strings"
+)
+
+// Bound is the plausible range of a shot field; a missing end is unbounded.
+type Bound struct {
+	Min *float64 `json:"min,omitempty"`
+	Max *float64 `json:"max,omitempty"`
+}
+
+// FieldBounds are bounds by field name. Ball fields are named directly, e.g. "TotalSpin",
+// and club fields with a "Club." prefix, e.g. "Club.Speed".
+type FieldBounds map[string]Bound
+
+// Bounds are the plausibility bounds shots are checked against: the default bounds,
+// which a club's own bounds override field by field. Speeds are in mph and distances in yards.
+type Bounds struct {
+	Default FieldBounds                     `json:"default"`
+	Clubs   map[Shared.ClubType]FieldBounds `json:"clubs"`
+}
+
+func bound(min, max float64) Bound {
+	return Bound{Min: &min, Max: &max}
+}
+
+// DefaultBounds are used without -validation-bounds-file. They only catch readings no real shot produces.
+var DefaultBounds = Bounds{
+	Default: FieldBounds{
+		"Speed":     bound(0, 220),
+		"TotalSpin": bound(0, 11000),
+		"HLA":       bound(-40, 40),
+		"VLA":       bound(-10, 70),
+		"SpinAxis":  bound(-60, 60),
+	},
+}
+
+// LoadBounds reads plausibility bounds from a YAML or JSON file and validates them.
+func LoadBounds(path string) (Bounds, error) {
+	var bounds Bounds
+	if err := Shared.ReadConfigFile(path, &bounds); err != nil {
+		return Bounds{}, err
+	}
+	if err := bounds.Default.validate(); err != nil {
+		return Bounds{}, fmt.Errorf("default bounds: %w", err)
+	}
+	for club, fields := range bounds.Clubs {
+		if !Shared.ValidClubTypes[club] {
+			return Bounds{}, fmt.Errorf("unknown club type %s", club)
+		}
+		if err := fields.validate(); err != nil {
+			return Bounds{}, fmt.Errorf("%s bounds: %w", club, err)
+		}
+	}
+	return bounds, nil
+}
+
+// validate checks that every bounded field exists and its bounds are in order.
+func (b FieldBounds) validate() error {
+	for name, bound := range b {
+		if _, ok := boundedField(name); !ok {
+			return fmt.Errorf("unknown field %s", name)
+		}
+		if bound.Min != nil && bound.Max != nil && *bound.Min > *bound.Max {
+			return fmt.Errorf("%s min %g is above max %g", name, *bound.Min, *bound.Max)
+		}
+	}
+	return nil
+}
+
+// boundedField finds the field of a shot a bound applies to, ignoring case.
+func boundedField(name string) (func(shot *Shot) float64, bool) {
+	if len(name) > len("Club.") && strings.EqualFold(name[:len("Club.")], "Club.") {
+		for _, f := range Shared.ClubFields {
+			if strings.EqualFold(f.Name, name[len("Club."):]) {
+				return func(shot *Shot) float64 { return *f.Value(&shot.Club) }, true
+			}
+		}
+		return nil, false
+	}
+	for _, f := range Shared.BallFields {
+		if strings.EqualFold(f.Name, name) {
+			return func(shot *Shot) float64 { return *f.Value(&shot.Ball) }, true
+		}
+	}
+	return nil, false
+}
+
+// Check returns why a shot is implausible for its club, or nothing when it is within bounds.
+// Club fields are only checked when the shot has club data.
+func (b Bounds) Check(shot *Shot) []string {
+	fields := FieldBounds{}
+	for name, bound := range b.Default {
+		fields[strings.ToLower(name)] = bound
+	}
+	for name, bound := range b.Clubs[Shared.ClubType(shot.Options.ClubType)] {
+		fields[strings.ToLower(name)] = bound
+	}
+
+	var reasons []string
+	for _, name := range boundedNames() {
+		bound, ok := fields[strings.ToLower(name)]
+		if !ok {
+			continue
+		}
+		if strings.HasPrefix(name, "Club.") && !shot.Options.ContainsClubData {
+			continue
+		}
+		value, _ := boundedField(name)
+		v := value(shot)
+		if bound.Min != nil && v < *bound.Min {
+			reasons = append(reasons, fmt.Sprintf("%s %.1f is below %g", name, v, *bound.Min))
+		}
+		if bound.Max != nil && v > *bound.Max {
+			reasons = append(reasons, fmt.Sprintf("%s %.1f is above %g", name, v, *bound.Max))
+		}
+	}
+	return reasons
+}
+
+// boundedNames lists every field that can be bounded, in declaration order.
+func boundedNames() []string {
+	var names []string
+	for _, f := range Shared.BallFields {
+		names = append(names, f.Name)
+	}
+	for _, f := range Shared.ClubFields {
+		names = append(names, "Club."+f.Name)
+	}
+	return names
+}
@@ -3,126 +3,280 @@ package Router
 import (
 	"Fairway_Bridge/Cameras"
 	"Fairway_Bridge/Launch_Monitors"
+	"Fairway_Bridge/Launch_Monitors/Fusion"
+	GSPro_OpenConnect "Fairway_Bridge/Launch_Monitors/GSPro-OpenConnect"
 	Garmin_R10 "Fairway_Bridge/Launch_Monitors/Garmin-R10"
+	Generic_JSON "Fairway_Bridge/Launch_Monitors/Generic-JSON"
+	"Fairway_Bridge/Launch_Monitors/Plugin"
+	"Fairway_Bridge/Launch_Monitors/Putting"
+	"Fairway_Bridge/Launch_Monitors/Replay"
+	"Fairway_Bridge/Launch_Monitors/Serial"
 	"Fairway_Bridge/Launch_Monitors/Virtual"
+	"Fairway_Bridge/Rules"
 	"Fairway_Bridge/Shared"
 	"Fairway_Bridge/Simulators"
+	"Fairway_Bridge/Simulators/E6"
+	"Fairway_Bridge/Simulators/Fanout"
 	"Fairway_Bridge/Simulators/GSPro"
+	SimulatorPlugin "Fairway_Bridge/Simulators/Plugin"
 	Virtual2 "Fairway_Bridge/Simulators/Virtual"
+	"Fairway_Bridge/Simulators/Webhook"
 	"Fairway_Bridge/Storage"
 	"fmt"
 	"go.uber.org/zap"
+	"sync"
 	"time"
 )
 
 // LaunchMonitorToSimulator initializes the launch monitor and simulator based on the provided configuration.
-func LaunchMonitorToSimulator(logger *zap.Logger, storage *Storage.FileStorage, camera Cameras.CameraController, config Shared.Config) (Launch_Monitors.LaunchMonitorController, Simulators.SimulatorController, error) {
+func LaunchMonitorToSimulator(logger *zap.Logger, storage *Storage.FileStorage, camera Cameras.CameraController, rules *Rules.Engine, quarantine *Quarantine, config Shared.Config) (Launch_Monitors.LaunchMonitorController, Simulators.SimulatorController, error) {
 	log := logger.With(zap.String("component", "ROUTER")).Sugar()
 
 	var launchMonitor Launch_Monitors.LaunchMonitorController
 	var simulator Simulators.SimulatorController
 
-	// Create the simulator
-	switch config.Simulator.Name {
-	case "GSPRO":
-		gsClient := GSPro.NewSimulator(config.Simulator.IPAddress, config.Simulator.Port, logger)
-		if err := gsClient.Connect(); err != nil {
-			return nil, nil, fmt.Errorf("failed to connect to GSPro: %v", err)
+	// Create the simulator, fanning shots out to several when a targets file is configured
+	if config.Simulator.Name == "FANOUT" {
+		targets, err := Fanout.LoadTargets(config.Simulator.TargetsFile)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to load simulator targets: %v", err)
 		}
-		simulator = gsClient
-	case "VIRTUAL":
-		simClient := Virtual2.NewSimulator(logger)
-		if err := simClient.Connect(); err != nil {
-			return nil, nil, fmt.Errorf("failed to connect to Virtual: %v", err)
+		var controllers []Fanout.Target
+		for _, target := range targets {
+			targetConfig := config
+			targetConfig.Simulator.IPAddress = target.IPAddress
+			targetConfig.Simulator.Port = target.Port
+			targetConfig.Simulator.QueueFile = target.QueueFile
+			if target.URL != "" {
+				targetConfig.Webhook.URL = target.URL
+			}
+			if target.Command != "" {
+				targetConfig.Plugin.SimulatorCommand = target.Command
+			}
+			if target.Units != "" {
+				targetConfig.Simulator.Units = Shared.UnitSystem(target.Units)
+			}
+			controller, err := newSimulator(target.Simulator, logger, targetConfig)
+			if err != nil {
+				return nil, nil, fmt.Errorf("target %s: %v", target.Name, err)
+			}
+			controllers = append(controllers, Fanout.Target{Name: target.Name, Controller: controller, Modifiers: target.Modifiers, Primary: target.Primary})
+		}
+		fanout := Fanout.NewSimulator(logger, controllers)
+		if err := fanout.Connect(); err != nil {
+			return nil, nil, fmt.Errorf("failed to start simulator fan-out: %v", err)
+		}
+		simulator = fanout
+	} else {
+		var err error
+		if simulator, err = newSimulator(config.Simulator.Name, logger, config); err != nil {
+			return nil, nil, err
 		}
-		simulator = simClient
-	default:
-		return nil, nil, fmt.Errorf("simulator %s is not supported", config.Simulator.Name)
 	}
 
-	// Create the launch monitor
-	switch config.LaunchMonitor.Name {
-	case "R10":
-		r10 := Garmin_R10.NewLaunchMonitor(config.Bridge.IPAddress, config.Bridge.Port, logger, config)
-		if err := r10.Connect(); err != nil {
-			return nil, nil, fmt.Errorf("failed to connect to R10: %v", err)
-		}
-		launchMonitor = r10
-	case "VIRTUAL":
-		virtual := Virtual.NewLaunchMonitor(logger)
-		if err := virtual.Connect(); err != nil {
-			return nil, nil, fmt.Errorf("failed to connect to R10: %v", err)
+	// Create the launch monitors, fusing them when more than one is configured
+	var devices []Fusion.Device
+	for _, name := range config.LaunchMonitor.Names {
+		device, err := newLaunchMonitor(name, logger, config)
+		if err != nil {
+			return nil, nil, err
 		}
-		launchMonitor = virtual
+		devices = append(devices, Fusion.Device{Name: name, Controller: device})
+	}
+	switch len(devices) {
+	case 0:
+		return nil, nil, fmt.Errorf("no launch monitor configured")
+	case 1:
+		launchMonitor = devices[0].Controller
 	default:
-		return nil, nil, fmt.Errorf("launch monitor %s is not supported", config.LaunchMonitor.Name)
+		policy, err := Fusion.ParsePolicy(config.Fusion.Policy, config.LaunchMonitor.Names)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to parse fusion policy: %v", err)
+		}
+		window := time.Duration(config.Fusion.WindowMilliseconds) * time.Millisecond
+		fusion := Fusion.NewLaunchMonitor(logger, devices, window, policy)
+		if err := fusion.Connect(); err != nil {
+			return nil, nil, fmt.Errorf("failed to fuse launch monitors: %v", err)
+		}
+		launchMonitor = fusion
 	}
 
-	// Create the shot callback function
-	launchMonitor.SetOnShotCallback(func(standardBall Shared.StandardizedBallData, standardClub Shared.StandardizedClubData, shotDataOptions Shared.ShotDataOptions) {
-		log.Infof("received shot callback from %s", config.LaunchMonitor.Name)
-
-		// Use Controllable Modifiers
-		modifiers := Shared.GetModifiers()
-
-		// Apply Multiplier Adjustment for ball & club data
-		adjustedBallOut := standardBall.ApplyAdjustment(modifiers.BallData)
-		adjustedClubOut := standardClub.ApplyAdjustment(modifiers.ClubData)
-
-		log.Infof("Ball Data Adjusted - Speed: %.2f -> %.2f, SpinAxis: %.2f -> %.2f, TotalSpin: %.2f -> %.2f, HLA: %.2f -> %.2f, VLA: %.2f -> %.2f",
-			standardBall.Speed, adjustedBallOut.Speed,
-			standardBall.SpinAxis, adjustedBallOut.SpinAxis,
-			standardBall.TotalSpin, adjustedBallOut.TotalSpin,
-			standardBall.HLA, adjustedBallOut.HLA,
-			standardBall.VLA, adjustedBallOut.VLA,
-		)
-
-		log.Infof("Club Data Adjusted - Speed: %.2f -> %.2f, AngleOfAttack: %.2f -> %.2f, FaceToTarget: %.2f -> %.2f, Lie: %.2f -> %.2f, Loft: %.2f -> %.2f, Path: %.2f -> %.2f, SpeedAtImpact: %.2f -> %.2f, VerticalFaceImpact: %.2f -> %.2f, HorizontalFaceImpact: %.2f -> %.2f, ClosureRate: %.2f -> %.2f",
-			standardClub.Speed, adjustedClubOut.Speed,
-			standardClub.AngleOfAttack, adjustedClubOut.AngleOfAttack,
-			standardClub.FaceToTarget, adjustedClubOut.FaceToTarget,
-			standardClub.Lie, adjustedClubOut.Lie,
-			standardClub.Loft, adjustedClubOut.Loft,
-			standardClub.Path, adjustedClubOut.Path,
-			standardClub.SpeedAtImpact, adjustedClubOut.SpeedAtImpact,
-			standardClub.VerticalFaceImpact, adjustedClubOut.VerticalFaceImpact,
-			standardClub.HorizontalFaceImpact, adjustedClubOut.HorizontalFaceImpact,
-			standardClub.ClosureRate, adjustedClubOut.ClosureRate,
-		)
-
-		// Send the shot to the simulator
-		if err := simulator.LaunchShot(adjustedBallOut, standardClub, shotDataOptions); err != nil {
-			log.Errorf("launching shot via %s: %v", config.Simulator.Name, err)
-		} else {
-			log.Infof("✅ shot sent to simulator successfully!")
+	// Add putting, taking putts from the HTTP API and an optional dedicated putting device
+	var puttingDevice Launch_Monitors.LaunchMonitorController
+	if config.Putting.Device != "" {
+		device, err := newLaunchMonitor(config.Putting.Device, logger, config)
+		if err != nil {
+			return nil, nil, fmt.Errorf("putting device: %v", err)
 		}
+		puttingDevice = device
+	}
+	putting := Putting.NewLaunchMonitor(logger, launchMonitor, puttingDevice, config)
+	if err := putting.Connect(); err != nil {
+		return nil, nil, fmt.Errorf("failed to start putting: %v", err)
+	}
+	launchMonitor = putting
+
+	// Keep the simulator's club so shots from devices that do not know the club are stored under it
+	var simulatorClub Shared.ClubType
+	var simulatorClubMutex sync.Mutex
 
-		// Save the shot data
-		if err := storage.SaveShot(time.Now(), standardBall, standardClub, adjustedBallOut, adjustedClubOut, shotDataOptions.ClubType); err != nil {
-			log.Errorf("saving shot: %v", err)
-		} else {
-			log.Infof("✅ shot saved successfully!")
+	// Forward simulator responses to launch monitors that relay them to the device
+	simulator.SetOnResponseCallback(func(response Shared.SimulatorResponse) {
+		log.Infof("received response from %s: Code=%d, Message=%s", config.Simulator.Name, response.Code, response.Message)
+		if relay, ok := launchMonitor.(Launch_Monitors.ResponseRelay); ok {
+			relay.RelayResponse(response)
 		}
 
-		// Stop the camera and save the recording
-		if camera != nil {
-			if err := camera.StopCapture(); err != nil {
-				log.Errorf("stopping camera: %v", err)
-			} else {
-				log.Infof("✅ camera stopped successfully!")
+		// Follow the player state reported by the simulator
+		if player := response.Player; player != nil {
+			log.Infof("player update from %s: Club=%s, Handed=%s, DistanceToTarget=%.1f, Surface=%s",
+				config.Simulator.Name, player.Club, player.Handed, player.DistanceToTarget, player.Surface)
+			if player.Handed != "" && player.Handed != Shared.GetHandedness() {
+				log.Infof("golfer handedness changed to %s", player.Handed)
+				Shared.SetHandedness(player.Handed)
 			}
-			if err := camera.SaveLastRecording(); err != nil {
-				log.Errorf("saving recording: %v", err)
-			} else {
-				log.Infof("✅ recording saved successfully!")
+			if player.Club != "" {
+				simulatorClubMutex.Lock()
+				changed := simulatorClub != player.Club
+				simulatorClub = player.Club
+				simulatorClubMutex.Unlock()
+				if changed {
+					if setter, ok := launchMonitor.(Launch_Monitors.ClubSetter); ok {
+						setter.SetClubType(player.Club)
+					}
+				}
 			}
 		}
 	})
 
-	// Start user input loop if the launch monitor is virtual
-	if config.LaunchMonitor.Name == "VIRTUAL" {
-		launchMonitor.LaunchShot()
+	// Process each shot through the configured pipeline of stages
+	stageConfigs, err := ParsePipeline(config.Pipeline.Stages)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to parse pipeline: %v", err)
+	}
+	pipeline, err := NewPipeline(stageConfigs, StageContext{
+		Logger:        logger,
+		Config:        config,
+		LaunchMonitor: launchMonitor,
+		Simulator:     simulator,
+		Storage:       storage,
+		Camera:        camera,
+		Rules:         rules,
+		Quarantine:    quarantine,
+		SimulatorClub: func() Shared.ClubType {
+			simulatorClubMutex.Lock()
+			defer simulatorClubMutex.Unlock()
+			return simulatorClub
+		},
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create pipeline: %v", err)
+	}
+	launchMonitor.SetOnShotCallback(func(standardBall Shared.StandardizedBallData, standardClub Shared.StandardizedClubData, shotDataOptions Shared.ShotDataOptions) *Shared.ShotResult {
+		log.Infof("received shot callback from %s", config.LaunchMonitor.Name)
+		return pipeline.Process(standardBall, standardClub, shotDataOptions)
+	})
+
+	// Start user input loop if a launch monitor is virtual, or start replaying recorded shots
+	for _, device := range devices {
+		if device.Name == "VIRTUAL" || device.Name == "REPLAY" {
+			device.Controller.LaunchShot()
+		}
 	}
 
 	return launchMonitor, simulator, nil
 }
+
+// newSimulator creates and connects the named simulator.
+func newSimulator(name string, logger *zap.Logger, config Shared.Config) (Simulators.SimulatorController, error) {
+	switch name {
+	case "GSPRO":
+		gsClient := GSPro.NewSimulator(config.Simulator.IPAddress, config.Simulator.Port, logger, config)
+		log := logger.With(zap.String("component", "ROUTER")).Sugar()
+		gsClient.SetOnConnectionCallback(func(status Shared.ConnectionStatus) {
+			if status.State == Shared.Disconnected && status.QueuedShots > 0 {
+				log.Warnf("GSPro at %s:%d is disconnected with %d queued shots", config.Simulator.IPAddress, config.Simulator.Port, status.QueuedShots)
+			}
+		})
+		if err := gsClient.Connect(); err != nil {
+			return nil, fmt.Errorf("failed to connect to GSPro: %v", err)
+		}
+		return gsClient, nil
+	case "E6":
+		e6Client := E6.NewSimulator(config.Simulator.IPAddress, config.Simulator.Port, logger, config)
+		if err := e6Client.Connect(); err != nil {
+			return nil, fmt.Errorf("failed to connect to E6: %v", err)
+		}
+		return e6Client, nil
+	case "VIRTUAL":
+		simClient := Virtual2.NewSimulator(logger, config)
+		if err := simClient.Connect(); err != nil {
+			return nil, fmt.Errorf("failed to connect to Virtual: %v", err)
+		}
+		return simClient, nil
+	case "WEBHOOK":
+		webhook := Webhook.NewSimulator(logger, config)
+		if err := webhook.Connect(); err != nil {
+			return nil, fmt.Errorf("failed to start webhook: %v", err)
+		}
+		return webhook, nil
+	case "PLUGIN":
+		plugin := SimulatorPlugin.NewSimulator(logger, config)
+		if err := plugin.Connect(); err != nil {
+			return nil, fmt.Errorf("failed to start simulator plugin: %v", err)
+		}
+		return plugin, nil
+	default:
+		return nil, fmt.Errorf("simulator %s is not supported", name)
+	}
+}
+
+// newLaunchMonitor creates and connects the named launch monitor.
+func newLaunchMonitor(name string, logger *zap.Logger, config Shared.Config) (Launch_Monitors.LaunchMonitorController, error) {
+	switch name {
+	case "R10":
+		r10 := Garmin_R10.NewLaunchMonitor(config.Bridge.IPAddress, config.Bridge.Port, logger, config)
+		if err := r10.Connect(); err != nil {
+			return nil, fmt.Errorf("failed to connect to R10: %v", err)
+		}
+		return r10, nil
+	case "OPENCONNECT":
+		openConnect := GSPro_OpenConnect.NewLaunchMonitor(config.Bridge.IPAddress, config.OpenConnect.Port, logger)
+		if err := openConnect.Connect(); err != nil {
+			return nil, fmt.Errorf("failed to start GSPro Open Connect server: %v", err)
+		}
+		return openConnect, nil
+	case "JSON":
+		generic := Generic_JSON.NewLaunchMonitor(config.Bridge.IPAddress, config.JSON.Port, logger, config)
+		if err := generic.Connect(); err != nil {
+			return nil, fmt.Errorf("failed to start JSON launch monitor: %v", err)
+		}
+		return generic, nil
+	case "SERIAL":
+		serial := Serial.NewLaunchMonitor(logger, config)
+		if err := serial.Connect(); err != nil {
+			return nil, fmt.Errorf("failed to open serial launch monitor: %v", err)
+		}
+		return serial, nil
+	case "PLUGIN":
+		plugin := Plugin.NewLaunchMonitor(logger, config)
+		if err := plugin.Connect(); err != nil {
+			return nil, fmt.Errorf("failed to start launch monitor plugin: %v", err)
+		}
+		return plugin, nil
+	case "VIRTUAL":
+		virtual := Virtual.NewLaunchMonitor(logger, config)
+		if err := virtual.Connect(); err != nil {
+			return nil, fmt.Errorf("failed to connect to Virtual: %v", err)
+		}
+		return virtual, nil
+	case "REPLAY":
+		replay := Replay.NewLaunchMonitor(logger, config)
+		if err := replay.Connect(); err != nil {
+			return nil, fmt.Errorf("failed to start replay: %v", err)
+		}
+		return replay, nil
+	default:
+		return nil, fmt.Errorf("launch monitor %s is not supported", name)
+	}
+}
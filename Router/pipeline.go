@@ -0,0 +1,309 @@
+package Router
+
+import (
+	"Fairway_Bridge/Cameras"
+	"Fairway_Bridge/Launch_Monitors"
+	"Fairway_Bridge/Rules"
+	"Fairway_Bridge/Shared"
+	"Fairway_Bridge/Simulators"
+	"Fairway_Bridge/Storage"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// ErrorPolicy decides what happens to a shot when one of its stages fails.
+type ErrorPolicy string
+
+const (
+	// PolicyAbort stops processing the shot; later stages do not see it.
+	PolicyAbort ErrorPolicy = "ABORT"
+	// PolicyContinue logs the error and passes the shot to the next stage.
+	PolicyContinue ErrorPolicy = "CONTINUE"
+)
+
+// ErrDropShot is returned by a stage that filters a shot out. The shot is not processed further,
+// whatever the stage's error policy, and the drop is logged as information rather than an error.
+var ErrDropShot = errors.New("shot dropped")
+
+// HoldError is returned by a stage that holds a shot in quarantine, such as a shot that looks like a misread.
+// The shot waits there until it is released, when it carries on from the next stage, or discarded.
+type HoldError struct {
+	Reasons []string
+}
+
+func (e *HoldError) Error() string {
+	return "shot held: " + strings.Join(e.Reasons, "; ")
+}
+
+// Shot is a shot moving through the pipeline. Stages read and update it in place.
+type Shot struct {
+	Timestamp time.Time
+	// Ball and Club are the shot as measured, in the launch monitor's convention until the convert stage runs.
+	Ball Shared.StandardizedBallData
+	Club Shared.StandardizedClubData
+	// AdjustedBall and AdjustedClub are the shot sent to the simulator; they start as a copy of Ball and Club.
+	AdjustedBall Shared.StandardizedBallData
+	AdjustedClub Shared.StandardizedClubData
+	Options      Shared.ShotDataOptions
+	Delivery     Shared.Delivery
+	Result       *Shared.ShotResult
+	// Metrics holds values derived from the shot by enrich stages, e.g. SmashFactor.
+	Metrics map[string]float64
+	// Flags labels the shot, e.g. "topped" from a rule.
+	Flags []string
+	// RulesInput is the shot as the rules stage received it, after the modifiers; nil when the stage did not run.
+	RulesInput *Rules.Shot
+	// Recorded is set once the camera has saved a recording of the shot.
+	Recorded bool
+
+	// sequence numbers the shots in the order they were taken.
+	sequence int
+}
+
+// Stage is one named step of the shot pipeline. Process may be called for several shots at once,
+// e.g. a released shot while the next one is taken, so stages must not keep per-shot state.
+type Stage interface {
+	Name() string
+	Process(shot *Shot) error
+}
+
+// ImmediateStage is implemented by stages that must run when the shot is taken, such as saving its recording,
+// even while the shot is held in quarantine. They do not run again when the shot is released.
+type ImmediateStage interface {
+	Stage
+	Immediate()
+}
+
+// StageContext gives stage factories the parts of the bridge a stage may need.
+type StageContext struct {
+	Logger        *zap.Logger
+	Config        Shared.Config
+	LaunchMonitor Launch_Monitors.LaunchMonitorController
+	Simulator     Simulators.SimulatorController
+	Storage       *Storage.FileStorage
+	Camera        Cameras.CameraController
+	Rules         *Rules.Engine
+	Quarantine    *Quarantine
+	// SimulatorClub returns the club last selected in the simulator, or "" when it has not reported one.
+	SimulatorClub func() Shared.ClubType
+}
+
+// StageFactory creates a stage for a pipeline.
+type StageFactory func(ctx StageContext) (Stage, error)
+
+// stageRegistration is a registered stage and the error policy it uses unless the pipeline says otherwise.
+type stageRegistration struct {
+	factory StageFactory
+	policy  ErrorPolicy
+}
+
+var (
+	stages      = map[string]stageRegistration{}
+	stagesMutex sync.RWMutex
+)
+
+// RegisterStage makes a stage available to -pipeline under the given name, with its default error policy.
+// Stages are usually registered from an init function, so new behaviour can be added in its own file.
+func RegisterStage(name string, policy ErrorPolicy, factory StageFactory) {
+	stagesMutex.Lock()
+	defer stagesMutex.Unlock()
+	stages[strings.ToUpper(name)] = stageRegistration{factory: factory, policy: policy}
+}
+
+// StageConfig is one entry of a pipeline definition.
+type StageConfig struct {
+	Name   string
+	Policy ErrorPolicy
+}
+
+// ParsePipeline parses a pipeline definition such as "convert,enrich=abort,validate,adjust,route,persist,capture".
+// Each entry names a registered stage, optionally followed by =abort or =continue to override its error policy.
+func ParsePipeline(value string) ([]StageConfig, error) {
+	stagesMutex.RLock()
+	defer stagesMutex.RUnlock()
+
+	var configs []StageConfig
+	seen := map[string]bool{}
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, policy, hasPolicy := strings.Cut(entry, "=")
+		name = strings.ToUpper(strings.TrimSpace(name))
+		registration, ok := stages[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown pipeline stage %s (%s)", name, strings.Join(stageNames(), ", "))
+		}
+		if seen[name] {
+			return nil, fmt.Errorf("pipeline stage %s is listed twice", name)
+		}
+		seen[name] = true
+
+		config := StageConfig{Name: name, Policy: registration.policy}
+		if hasPolicy {
+			switch p := ErrorPolicy(strings.ToUpper(strings.TrimSpace(policy))); p {
+			case PolicyAbort, PolicyContinue:
+				config.Policy = p
+			default:
+				return nil, fmt.Errorf("pipeline stage %s has unknown error policy %q (abort, continue)", name, policy)
+			}
+		}
+		configs = append(configs, config)
+	}
+	if len(configs) == 0 {
+		return nil, fmt.Errorf("pipeline has no stages")
+	}
+	return configs, nil
+}
+
+// stageNames lists the registered stages in alphabetical order. The caller must hold stagesMutex.
+func stageNames() []string {
+	names := make([]string, 0, len(stages))
+	for name := range stages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// pipelineStage is a created stage and the error policy it runs with.
+type pipelineStage struct {
+	stage  Stage
+	policy ErrorPolicy
+}
+
+// Pipeline passes each shot through its stages in order.
+type Pipeline struct {
+	stages     []pipelineStage
+	quarantine *Quarantine
+	camera     Cameras.CameraController
+	log        *zap.SugaredLogger
+
+	// mu guards the shot sequence only. Shots run through the stages outside it, so a shot waiting on the
+	// simulator does not hold up the next shot or the quarantine.
+	mu       sync.Mutex
+	sequence int
+}
+
+// NewPipeline creates the stages of a pipeline definition.
+func NewPipeline(configs []StageConfig, ctx StageContext) (*Pipeline, error) {
+	p := &Pipeline{quarantine: ctx.Quarantine, camera: ctx.Camera, log: ctx.Logger.With(zap.String("component", "PIPELINE")).Sugar()}
+	var names []string
+	for _, config := range configs {
+		stagesMutex.RLock()
+		registration, ok := stages[config.Name]
+		stagesMutex.RUnlock()
+		if !ok {
+			return nil, fmt.Errorf("unknown pipeline stage %s", config.Name)
+		}
+		stage, err := registration.factory(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("creating pipeline stage %s: %w", config.Name, err)
+		}
+		p.stages = append(p.stages, pipelineStage{stage: stage, policy: config.Policy})
+		names = append(names, fmt.Sprintf("%s (%s)", stage.Name(), config.Policy))
+	}
+	if p.quarantine != nil {
+		p.quarantine.attach(p)
+	}
+	p.log.Infof("✅ shots are processed by %s", strings.Join(names, " → "))
+	return p, nil
+}
+
+// Process runs a shot from a launch monitor through the stages and returns the simulator's result, if any.
+func (p *Pipeline) Process(ball Shared.StandardizedBallData, club Shared.StandardizedClubData, options Shared.ShotDataOptions) *Shared.ShotResult {
+	p.mu.Lock()
+	p.sequence++
+	sequence := p.sequence
+	p.mu.Unlock()
+
+	shot := &Shot{
+		Timestamp:    time.Now(),
+		Ball:         ball,
+		Club:         club,
+		AdjustedBall: ball,
+		AdjustedClub: club,
+		Options:      options,
+		Metrics:      map[string]float64{},
+		sequence:     sequence,
+	}
+	return p.run(shot, 0, false)
+}
+
+// run passes a shot through the stages from the given one. Immediate stages are skipped for released shots,
+// as they already ran when the shot was held.
+func (p *Pipeline) run(shot *Shot, from int, released bool) *Shared.ShotResult {
+	for i := from; i < len(p.stages); i++ {
+		s := p.stages[i]
+		if _, ok := s.stage.(ImmediateStage); ok && released {
+			continue
+		}
+		err := s.stage.Process(shot)
+		if err == nil {
+			continue
+		}
+		var hold *HoldError
+		if errors.As(err, &hold) {
+			p.hold(shot, i, hold.Reasons)
+			return nil
+		}
+		if errors.Is(err, ErrDropShot) {
+			p.log.Infof("stage %s dropped the shot: %v", s.stage.Name(), err)
+			return shot.Result
+		}
+		if s.policy == PolicyAbort {
+			p.log.Errorf("stage %s: %v, the shot is not processed further", s.stage.Name(), err)
+			return shot.Result
+		}
+		p.log.Errorf("stage %s: %v", s.stage.Name(), err)
+	}
+	return shot.Result
+}
+
+// hold puts a shot in quarantine after the stage at index held, and runs the immediate stages after it.
+func (p *Pipeline) hold(shot *Shot, held int, reasons []string) {
+	name := p.stages[held].stage.Name()
+	if p.quarantine == nil {
+		p.log.Warnf("stage %s held the shot but there is no quarantine, the shot is dropped: %s", name, strings.Join(reasons, "; "))
+		return
+	}
+	p.log.Warnf("stage %s held the shot in quarantine: %s", name, strings.Join(reasons, "; "))
+	for _, s := range p.stages[held+1:] {
+		if _, ok := s.stage.(ImmediateStage); !ok {
+			continue
+		}
+		if err := s.stage.Process(shot); err != nil {
+			p.log.Errorf("stage %s: %v", s.stage.Name(), err)
+		}
+	}
+	p.quarantine.add(shot, held, reasons)
+}
+
+// release passes a shot held in quarantine through the stages after the one that held it.
+func (p *Pipeline) release(shot *Shot, held int) *Shared.ShotResult {
+	return p.run(shot, held+1, true)
+}
+
+// recheck runs the stage that held a shot again, after the shot was edited, and returns why it would still hold it.
+func (p *Pipeline) recheck(shot *Shot, held int) ([]string, error) {
+	err := p.stages[held].stage.Process(shot)
+	var hold *HoldError
+	if errors.As(err, &hold) {
+		return hold.Reasons, nil
+	}
+	return nil, err
+}
+
+// latest reports whether no shot has been taken since the given one.
+func (p *Pipeline) latest(shot *Shot) bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return shot.sequence == p.sequence
+}
@@ -0,0 +1,195 @@
+package Router
+
+import (
+	"Fairway_Bridge/Shared"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// quarantineLimit is the number of shots held in quarantine; the oldest is discarded when it is full.
+const quarantineLimit = 50
+
+// ErrShotNotFound is returned for a quarantined shot that does not exist, or no longer does.
+var ErrShotNotFound = errors.New("no such shot in quarantine")
+
+// QuarantinedShot is a shot held in quarantine, as measured and in the standard convention.
+type QuarantinedShot struct {
+	ID        int                         `json:"ID"`
+	Timestamp time.Time                   `json:"Timestamp"`
+	ClubType  string                      `json:"ClubType"`
+	Ball      Shared.StandardizedBallData `json:"Ball"`
+	Club      Shared.StandardizedClubData `json:"Club"`
+	// Reasons says why the shot was held. It is empty once an edit has fixed the shot.
+	Reasons  []string `json:"Reasons"`
+	Recorded bool     `json:"Recorded"`
+}
+
+// quarantineEntry is a held shot and the stage that held it.
+type quarantineEntry struct {
+	id      int
+	shot    *Shot
+	held    int
+	reasons []string
+}
+
+func (e *quarantineEntry) view() QuarantinedShot {
+	return QuarantinedShot{
+		ID:        e.id,
+		Timestamp: e.shot.Timestamp,
+		ClubType:  e.shot.Options.ClubType,
+		Ball:      e.shot.Ball,
+		Club:      e.shot.Club,
+		Reasons:   append([]string{}, e.reasons...),
+		Recorded:  e.shot.Recorded,
+	}
+}
+
+// Quarantine holds the shots a pipeline stage did not trust, such as likely misreads,
+// until they are released to the rest of the pipeline, edited or discarded.
+type Quarantine struct {
+	log      *zap.SugaredLogger
+	pipeline *Pipeline
+
+	mu      sync.Mutex
+	entries map[int]*quarantineEntry
+	nextID  int
+}
+
+// NewQuarantine creates an empty quarantine. It holds shots once a pipeline is created with it.
+func NewQuarantine(logger *zap.Logger) *Quarantine {
+	return &Quarantine{
+		log:     logger.With(zap.String("component", "QUARANTINE")).Sugar(),
+		entries: map[int]*quarantineEntry{},
+		nextID:  1,
+	}
+}
+
+// attach makes the quarantine release shots to a pipeline.
+func (q *Quarantine) attach(p *Pipeline) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.pipeline = p
+}
+
+// add holds a shot held by the stage at index held.
+func (q *Quarantine) add(shot *Shot, held int, reasons []string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if len(q.entries) >= quarantineLimit {
+		oldest := 0
+		for id := range q.entries {
+			if oldest == 0 || id < oldest {
+				oldest = id
+			}
+		}
+		delete(q.entries, oldest)
+		q.log.Warnf("quarantine is full, discarded shot %d", oldest)
+	}
+	id := q.nextID
+	q.nextID++
+	q.entries[id] = &quarantineEntry{id: id, shot: shot, held: held, reasons: reasons}
+	q.log.Infof("holding shot %d", id)
+}
+
+// take removes a shot from quarantine.
+func (q *Quarantine) take(id int) (*quarantineEntry, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	entry, ok := q.entries[id]
+	if !ok {
+		return nil, ErrShotNotFound
+	}
+	delete(q.entries, id)
+	return entry, nil
+}
+
+// List returns the shots in quarantine, oldest first.
+func (q *Quarantine) List() []QuarantinedShot {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	shots := make([]QuarantinedShot, 0, len(q.entries))
+	for _, entry := range q.entries {
+		shots = append(shots, entry.view())
+	}
+	sort.Slice(shots, func(i, j int) bool { return shots[i].ID < shots[j].ID })
+	return shots
+}
+
+// Get returns a shot in quarantine.
+func (q *Quarantine) Get(id int) (QuarantinedShot, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	entry, ok := q.entries[id]
+	if !ok {
+		return QuarantinedShot{}, ErrShotNotFound
+	}
+	return entry.view(), nil
+}
+
+// Release sends a shot on through the rest of the pipeline, whether or not it still looks like a misread,
+// and returns the simulator's result, if any.
+func (q *Quarantine) Release(id int) (*Shared.ShotResult, error) {
+	entry, err := q.take(id)
+	if err != nil {
+		return nil, err
+	}
+	q.log.Infof("releasing shot %d", id)
+	return q.pipeline.release(entry.shot, entry.held), nil
+}
+
+// Edit replaces the measured data and club of a shot in quarantine, which stays there until it is released.
+// The shot is checked again, so the returned shot has no reasons when the edit fixed it.
+func (q *Quarantine) Edit(id int, ball Shared.StandardizedBallData, club Shared.StandardizedClubData, clubType string) (QuarantinedShot, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	entry, ok := q.entries[id]
+	if !ok {
+		return QuarantinedShot{}, ErrShotNotFound
+	}
+
+	shot := *entry.shot
+	shot.Ball, shot.AdjustedBall = ball, ball
+	shot.Club, shot.AdjustedClub = club, club
+	shot.Options.ClubType = clubType
+	shot.Metrics = map[string]float64{}
+	if shot.Options.ContainsClubData {
+		deriveMetrics(&shot)
+	}
+	reasons, err := q.pipeline.recheck(&shot, entry.held)
+	if err != nil {
+		return QuarantinedShot{}, fmt.Errorf("checking shot: %w", err)
+	}
+
+	entry.shot, entry.reasons = &shot, reasons
+	q.log.Infof("edited shot %d", id)
+	return entry.view(), nil
+}
+
+// Discard drops a shot from quarantine and deletes its recording. The recording is only deleted while it is
+// still the camera's last one, that is when no shot has been taken since; the result says whether it was.
+func (q *Quarantine) Discard(id int) (bool, error) {
+	entry, err := q.take(id)
+	if err != nil {
+		return false, err
+	}
+	q.log.Infof("discarding shot %d", id)
+
+	camera := q.pipeline.camera
+	if camera == nil || !entry.shot.Recorded {
+		return false, nil
+	}
+	if !q.pipeline.latest(entry.shot) {
+		q.log.Warnf("not deleting the recording of shot %d as newer shots have been recorded since", id)
+		return false, nil
+	}
+	if err := camera.DeleteLastRecording(); err != nil {
+		return false, fmt.Errorf("deleting recording: %w", err)
+	}
+	return true, nil
+}
@@ -0,0 +1,327 @@
+package Router
+
+import (
+	"Fairway_Bridge/Cameras"
+	"Fairway_Bridge/Launch_Monitors"
+	"Fairway_Bridge/Rules"
+	"Fairway_Bridge/Shared"
+	"Fairway_Bridge/Simulators"
+	"Fairway_Bridge/Storage"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// The built-in stages, in the order of the default -pipeline.
+func init() {
+	RegisterStage("CONVERT", PolicyAbort, func(ctx StageContext) (Stage, error) {
+		return &convertStage{launchMonitor: ctx.LaunchMonitor}, nil
+	})
+	RegisterStage("ENRICH", PolicyContinue, func(ctx StageContext) (Stage, error) {
+		return &enrichStage{simulatorClub: ctx.SimulatorClub, log: stageLogger(ctx, "ENRICH")}, nil
+	})
+	RegisterStage("VALIDATE", PolicyAbort, func(ctx StageContext) (Stage, error) {
+		bounds := DefaultBounds
+		if ctx.Config.Pipeline.BoundsFile != "" {
+			var err error
+			if bounds, err = LoadBounds(ctx.Config.Pipeline.BoundsFile); err != nil {
+				return nil, fmt.Errorf("loading validation bounds: %w", err)
+			}
+		}
+		return &validateStage{bounds: bounds}, nil
+	})
+	RegisterStage("ADJUST", PolicyAbort, func(ctx StageContext) (Stage, error) {
+		return &adjustStage{log: stageLogger(ctx, "ADJUST")}, nil
+	})
+	RegisterStage("RULES", PolicyContinue, func(ctx StageContext) (Stage, error) {
+		if ctx.Rules == nil {
+			return nil, fmt.Errorf("no rules engine")
+		}
+		return &rulesStage{rules: ctx.Rules, log: stageLogger(ctx, "RULES")}, nil
+	})
+	RegisterStage("ROUTE", PolicyContinue, func(ctx StageContext) (Stage, error) {
+		return &routeStage{simulator: ctx.Simulator, name: ctx.Config.Simulator.Name, log: stageLogger(ctx, "ROUTE")}, nil
+	})
+	RegisterStage("PERSIST", PolicyContinue, func(ctx StageContext) (Stage, error) {
+		if ctx.Storage == nil {
+			return nil, fmt.Errorf("no shot storage")
+		}
+		return &persistStage{storage: ctx.Storage, log: stageLogger(ctx, "PERSIST")}, nil
+	})
+	RegisterStage("CAPTURE", PolicyContinue, func(ctx StageContext) (Stage, error) {
+		return &captureStage{camera: ctx.Camera, log: stageLogger(ctx, "CAPTURE")}, nil
+	})
+}
+
+// stageLogger returns the logger of a built-in stage.
+func stageLogger(ctx StageContext, name string) *zap.SugaredLogger {
+	return ctx.Logger.With(zap.String("component", "PIPELINE"), zap.String("type", name)).Sugar()
+}
+
+// validateStage rejects shots with values that are not numbers and holds shots that look like misreads,
+// with no ball speed or values outside the plausibility bounds of their club, in quarantine.
+type validateStage struct {
+	bounds Bounds
+}
+
+func (s *validateStage) Name() string { return "VALIDATE" }
+
+func (s *validateStage) Process(shot *Shot) error {
+	for _, f := range Shared.BallFields {
+		if v := *f.Value(&shot.Ball); math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("ball %s is %v", f.Name, v)
+		}
+	}
+	for _, f := range Shared.ClubFields {
+		if v := *f.Value(&shot.Club); math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("club %s is %v", f.Name, v)
+		}
+	}
+
+	var reasons []string
+	if shot.Ball.Speed <= 0 {
+		reasons = append(reasons, fmt.Sprintf("ball speed %.1f is not positive", shot.Ball.Speed))
+	}
+	reasons = append(reasons, s.bounds.Check(shot)...)
+	if len(reasons) > 0 {
+		return &HoldError{Reasons: reasons}
+	}
+	return nil
+}
+
+// convertStage converts the shot from the launch monitor's native convention to the standard convention.
+type convertStage struct {
+	launchMonitor Launch_Monitors.LaunchMonitorController
+}
+
+func (s *convertStage) Name() string { return "CONVERT" }
+
+func (s *convertStage) Process(shot *Shot) error {
+	handed := Shared.GetHandedness()
+	from := s.launchMonitor.Convention()
+	shot.Ball = Shared.ConvertBallData(shot.Ball, from, Shared.StandardConvention, handed)
+	shot.Club = Shared.ConvertClubData(shot.Club, from, Shared.StandardConvention, handed)
+	shot.AdjustedBall = Shared.ConvertBallData(shot.AdjustedBall, from, Shared.StandardConvention, handed)
+	shot.AdjustedClub = Shared.ConvertClubData(shot.AdjustedClub, from, Shared.StandardConvention, handed)
+	return nil
+}
+
+// enrichStage fills in the simulator's club for devices that do not know it and derives metrics from the shot.
+type enrichStage struct {
+	simulatorClub func() Shared.ClubType
+	log           *zap.SugaredLogger
+}
+
+func (s *enrichStage) Name() string { return "ENRICH" }
+
+func (s *enrichStage) Process(shot *Shot) error {
+	if shot.Options.ClubType == "" && s.simulatorClub != nil {
+		shot.Options.ClubType = string(s.simulatorClub())
+	}
+
+	if shot.Options.ContainsClubData {
+		deriveMetrics(shot)
+		s.log.Infof("derived metrics: SmashFactor=%.2f, FaceToPath=%.1f, SpinLoft=%.1f",
+			shot.Metrics["SmashFactor"], shot.Metrics["FaceToPath"], shot.Metrics["SpinLoft"])
+	}
+	return nil
+}
+
+// deriveMetrics computes the metrics that follow from the measured ball and club data.
+func deriveMetrics(shot *Shot) {
+	if shot.Club.Speed > 0 {
+		shot.Metrics["SmashFactor"] = shot.Ball.Speed / shot.Club.Speed
+	}
+	shot.Metrics["FaceToPath"] = shot.Club.FaceToTarget - shot.Club.Path
+	shot.Metrics["SpinLoft"] = shot.Club.Loft - shot.Club.AngleOfAttack
+}
+
+// adjustStage applies the modifiers set in the UI to the shot sent to the simulator,
+// using the club's modifier profile when it has one.
+type adjustStage struct {
+	log *zap.SugaredLogger
+}
+
+func (s *adjustStage) Name() string { return "ADJUST" }
+
+func (s *adjustStage) Process(shot *Shot) error {
+	if shot.Options.Adjusted {
+		s.log.Infof("shot was already adjusted, leaving it unchanged")
+		return nil
+	}
+	club := Shared.ClubType(shot.Options.ClubType)
+	modifiers, profile := Shared.ModifiersFor(club)
+	if profile {
+		s.log.Infof("using the %s modifier profile", club)
+	}
+	before, beforeClub := shot.AdjustedBall, shot.AdjustedClub
+	shot.AdjustedBall = shot.AdjustedBall.ApplyAdjustment(modifiers.BallData)
+	shot.AdjustedClub = shot.AdjustedClub.ApplyAdjustment(modifiers.ClubData)
+
+	s.log.Infof("Ball Data Adjusted - Speed: %.2f -> %.2f, SpinAxis: %.2f -> %.2f, TotalSpin: %.2f -> %.2f, HLA: %.2f -> %.2f, VLA: %.2f -> %.2f",
+		before.Speed, shot.AdjustedBall.Speed,
+		before.SpinAxis, shot.AdjustedBall.SpinAxis,
+		before.TotalSpin, shot.AdjustedBall.TotalSpin,
+		before.HLA, shot.AdjustedBall.HLA,
+		before.VLA, shot.AdjustedBall.VLA,
+	)
+
+	s.log.Infof("Club Data Adjusted - Speed: %.2f -> %.2f, AngleOfAttack: %.2f -> %.2f, FaceToTarget: %.2f -> %.2f, Lie: %.2f -> %.2f, Loft: %.2f -> %.2f, Path: %.2f -> %.2f, SpeedAtImpact: %.2f -> %.2f, VerticalFaceImpact: %.2f -> %.2f, HorizontalFaceImpact: %.2f -> %.2f, ClosureRate: %.2f -> %.2f",
+		beforeClub.Speed, shot.AdjustedClub.Speed,
+		beforeClub.AngleOfAttack, shot.AdjustedClub.AngleOfAttack,
+		beforeClub.FaceToTarget, shot.AdjustedClub.FaceToTarget,
+		beforeClub.Lie, shot.AdjustedClub.Lie,
+		beforeClub.Loft, shot.AdjustedClub.Loft,
+		beforeClub.Path, shot.AdjustedClub.Path,
+		beforeClub.SpeedAtImpact, shot.AdjustedClub.SpeedAtImpact,
+		beforeClub.VerticalFaceImpact, shot.AdjustedClub.VerticalFaceImpact,
+		beforeClub.HorizontalFaceImpact, shot.AdjustedClub.HorizontalFaceImpact,
+		beforeClub.ClosureRate, shot.AdjustedClub.ClosureRate,
+	)
+	return nil
+}
+
+// rulesStage applies the conditional rules of the rules file to the shot sent to the simulator.
+type rulesStage struct {
+	rules *Rules.Engine
+	log   *zap.SugaredLogger
+}
+
+func (s *rulesStage) Name() string { return "RULES" }
+
+func (s *rulesStage) Process(shot *Shot) error {
+	if shot.Options.Adjusted {
+		s.log.Infof("shot was already adjusted, skipping the rules")
+		return nil
+	}
+	subject := &Rules.Shot{
+		Ball:     shot.AdjustedBall,
+		Club:     shot.AdjustedClub,
+		ClubType: shot.Options.ClubType,
+		Metrics:  shot.Metrics,
+		Flags:    shot.Flags,
+	}
+	input := *subject
+	shot.RulesInput = &input
+	outcomes, errs := s.rules.Apply(subject)
+	for _, outcome := range outcomes {
+		if !outcome.Matched {
+			continue
+		}
+		for _, change := range outcome.Changes {
+			s.log.Infof("rule %s: %s %.2f -> %.2f", outcome.Rule, change.Field, change.From, change.To)
+		}
+		if outcome.Flag != "" {
+			s.log.Infof("rule %s: flagged the shot as %s", outcome.Rule, outcome.Flag)
+		}
+	}
+	shot.AdjustedBall, shot.AdjustedClub, shot.Flags = subject.Ball, subject.Club, subject.Flags
+	return errors.Join(errs...)
+}
+
+// routeStage sends the adjusted shot to the simulator in its native convention and records the outcome.
+// A shot the simulator did not take is recorded in the delivery rather than returned as an error,
+// so it is still stored.
+type routeStage struct {
+	simulator Simulators.SimulatorController
+	name      string
+	log       *zap.SugaredLogger
+	// resultMutex keeps another shot from being sent between a shot and the read of its result,
+	// for simulators that report the result of their last shot.
+	resultMutex sync.Mutex
+}
+
+func (s *routeStage) Name() string { return "ROUTE" }
+
+func (s *routeStage) Process(shot *Shot) error {
+	handed := Shared.GetHandedness()
+	simBall := Shared.ConvertBallData(shot.AdjustedBall, Shared.StandardConvention, s.simulator.Convention(), handed)
+	simClub := Shared.ConvertClubData(shot.AdjustedClub, Shared.StandardConvention, s.simulator.Convention(), handed)
+
+	reporter, reports := s.simulator.(Simulators.ShotResultReporter)
+	if reports {
+		s.resultMutex.Lock()
+		defer s.resultMutex.Unlock()
+	}
+	err := s.simulator.LaunchShot(simBall, simClub, shot.Options)
+	shot.Delivery = Simulators.DeliveryOf(err)
+	if errors.Is(err, Simulators.ErrShotQueued) {
+		s.log.Warnf("%s is disconnected, the shot will be sent when it reconnects", s.name)
+	} else if err != nil {
+		s.log.Errorf("launching shot via %s: %v", s.name, err)
+	} else {
+		s.log.Infof("✅ shot sent to simulator successfully!")
+		if reports {
+			if result, ok := reporter.LastShotResult(); ok {
+				result = Shared.ConvertShotResult(result, s.simulator.Convention(), Shared.StandardConvention, handed)
+				shot.Result = &result
+			}
+		}
+	}
+	return nil
+}
+
+// persistStage adds the shot to the recent shot history and saves it to the shot file.
+type persistStage struct {
+	storage *Storage.FileStorage
+	log     *zap.SugaredLogger
+}
+
+func (s *persistStage) Name() string { return "PERSIST" }
+
+func (s *persistStage) Process(shot *Shot) error {
+	rulesBall, rulesClub := shot.AdjustedBall, shot.AdjustedClub
+	if shot.RulesInput != nil {
+		rulesBall, rulesClub = shot.RulesInput.Ball, shot.RulesInput.Club
+	}
+	Shared.AddRecentShot(Shared.RecentShot{
+		Timestamp: shot.Timestamp,
+		ClubType:  shot.Options.ClubType,
+		Ball:      shot.AdjustedBall,
+		Club:      shot.AdjustedClub,
+		Delivery:  shot.Delivery,
+		Result:    shot.Result,
+		Metrics:   shot.Metrics,
+		Flags:     shot.Flags,
+		RulesBall: rulesBall,
+		RulesClub: rulesClub,
+	})
+	if err := s.storage.SaveShot(shot.Timestamp, shot.Ball, shot.Club, shot.AdjustedBall, shot.AdjustedClub, shot.Options.ClubType, shot.Delivery, shot.Result, shot.Options.Sources); err != nil {
+		return fmt.Errorf("saving shot: %w", err)
+	}
+	s.log.Infof("✅ shot saved successfully!")
+	return nil
+}
+
+// captureStage stops the camera and saves the recording of the shot. It runs as soon as the shot is taken,
+// even when the shot is held in quarantine, as the camera discards recordings that are not saved in time.
+type captureStage struct {
+	camera Cameras.CameraController
+	log    *zap.SugaredLogger
+}
+
+func (s *captureStage) Name() string { return "CAPTURE" }
+
+func (s *captureStage) Immediate() {}
+
+func (s *captureStage) Process(shot *Shot) error {
+	if s.camera == nil {
+		return nil
+	}
+	var errs []error
+	if err := s.camera.StopCapture(); err != nil {
+		errs = append(errs, fmt.Errorf("stopping camera: %w", err))
+	} else {
+		s.log.Infof("✅ camera stopped successfully!")
+	}
+	if err := s.camera.SaveLastRecording(); err != nil {
+		errs = append(errs, fmt.Errorf("saving recording: %w", err))
+	} else {
+		shot.Recorded = true
+		s.log.Infof("✅ recording saved successfully!")
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,142 @@
+package Rules
+
+import (
+	"Fairway_Bridge/Shared"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// rulesFile is the layout of a rules file.
+type rulesFile struct {
+	Rules []Rule `json:"rules"`
+}
+
+// Status describes the rules file and the rules in use.
+type Status struct {
+	File     string    `json:"File"`
+	LoadedAt time.Time `json:"LoadedAt,omitempty"`
+	// Error is why the last change to the file was not loaded; the previous rules stay in use.
+	Error string `json:"Error,omitempty"`
+	Rules []Rule `json:"Rules"`
+}
+
+// Engine applies the rules of a rules file to shots and reloads the file when it changes.
+// Without a file it has no rules.
+type Engine struct {
+	path string
+	log  *zap.SugaredLogger
+
+	mu       sync.Mutex
+	rules    []*CompiledRule
+	modTime  time.Time
+	loadedAt time.Time
+	loadErr  error
+}
+
+// NewEngine loads the rules of -rules-file. A rules file that cannot be loaded at startup is an error.
+func NewEngine(logger *zap.Logger, config Shared.Config) (*Engine, error) {
+	e := &Engine{
+		path: config.Pipeline.RulesFile,
+		log:  logger.With(zap.String("component", "RULES")).Sugar(),
+	}
+	if e.path == "" {
+		return e, nil
+	}
+
+	info, err := os.Stat(e.path)
+	if err != nil {
+		return nil, fmt.Errorf("reading rules: %w", err)
+	}
+	rules, err := LoadRules(e.path)
+	if err != nil {
+		return nil, err
+	}
+	e.rules, e.modTime, e.loadedAt = rules, info.ModTime(), time.Now()
+	e.log.Infof("✅ loaded %d rules from %s", len(rules), e.path)
+	return e, nil
+}
+
+// LoadRules reads the rules from a YAML or JSON file and compiles them.
+func LoadRules(path string) ([]*CompiledRule, error) {
+	var file rulesFile
+	if err := Shared.ReadConfigFile(path, &file); err != nil {
+		return nil, err
+	}
+	names := map[string]bool{}
+	var rules []*CompiledRule
+	for _, rule := range file.Rules {
+		if names[rule.Name] {
+			return nil, fmt.Errorf("rule %s is defined twice", rule.Name)
+		}
+		names[rule.Name] = true
+		compiled, err := Compile(rule)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, compiled)
+	}
+	return rules, nil
+}
+
+// reload loads the rules file again when it has changed since it was last read. A file that does not load
+// is logged once and the previous rules stay in use. The caller must hold e.mu.
+func (e *Engine) reload() {
+	if e.path == "" {
+		return
+	}
+	info, err := os.Stat(e.path)
+	if err != nil || info.ModTime().Equal(e.modTime) {
+		return
+	}
+	e.modTime = info.ModTime()
+
+	rules, err := LoadRules(e.path)
+	if err != nil {
+		e.loadErr = err
+		e.log.Errorf("keeping the previous %d rules: %v", len(e.rules), err)
+		return
+	}
+	e.rules, e.loadedAt, e.loadErr = rules, time.Now(), nil
+	e.log.Infof("✅ reloaded %d rules from %s", len(rules), e.path)
+}
+
+// Apply runs every rule against a shot in file order, so later rules see the changes and flags of earlier ones.
+// A rule that fails to evaluate is skipped and its error returned with the others.
+func (e *Engine) Apply(shot *Shot) ([]Outcome, []error) {
+	e.mu.Lock()
+	e.reload()
+	rules := e.rules
+	e.mu.Unlock()
+
+	var outcomes []Outcome
+	var errs []error
+	for _, rule := range rules {
+		outcome, err := rule.Apply(shot)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		outcomes = append(outcomes, outcome)
+	}
+	return outcomes, errs
+}
+
+// Status returns the rules file and the rules in use.
+func (e *Engine) Status() Status {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.reload()
+
+	status := Status{File: e.path, LoadedAt: e.loadedAt, Rules: []Rule{}}
+	if e.loadErr != nil {
+		status.Error = e.loadErr.Error()
+	}
+	for _, rule := range e.rules {
+		status.Rules = append(status.Rules, rule.Rule)
+	}
+	return status
+}
@@ -0,0 +1,186 @@
+package Rules
+
+import (
+	"Fairway_Bridge/Shared"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/expr-lang/expr"
+	"github.com/expr-lang/expr/vm"
+)
+
+// Rule changes or flags the shots that match its condition, e.g.
+//
+//	name: driver-spin
+//	when: Speed > 140 and ClubType == "Driver"
+//	set:
+//	  TotalSpin: TotalSpin * 0.85
+//
+// Conditions and values are expressions over the shot: the ball fields by name (Speed, TotalSpin, VLA...),
+// the club fields under Club (Club.Speed, Club.Path...), ClubType, Metrics and the Flags set by earlier rules.
+// Speeds are in mph and distances in yards.
+type Rule struct {
+	Name string `json:"name"`
+	When string `json:"when"`
+	// Set maps a field to the expression giving its new value; club fields are prefixed with "Club.".
+	Set map[string]string `json:"set,omitempty"`
+	// Flag is added to the shot's flags when the rule matches, e.g. "topped".
+	Flag string `json:"flag,omitempty"`
+}
+
+// Shot is what a rule sees and changes.
+type Shot struct {
+	Ball     Shared.StandardizedBallData
+	Club     Shared.StandardizedClubData
+	ClubType string
+	Metrics  map[string]float64
+	Flags    []string
+}
+
+// env is the expression environment of a shot. The ball fields are embedded so rules can name them directly.
+type env struct {
+	Shared.StandardizedBallData
+	Club     Shared.StandardizedClubData
+	ClubType string
+	Metrics  map[string]float64
+	Flags    []string
+}
+
+func envOf(shot *Shot) env {
+	metrics := shot.Metrics
+	if metrics == nil {
+		metrics = map[string]float64{}
+	}
+	return env{StandardizedBallData: shot.Ball, Club: shot.Club, ClubType: shot.ClubType, Metrics: metrics, Flags: shot.Flags}
+}
+
+// Change is a field a rule changed.
+type Change struct {
+	Field string  `json:"Field"`
+	From  float64 `json:"From"`
+	To    float64 `json:"To"`
+}
+
+// Outcome is what a rule did to a shot.
+type Outcome struct {
+	Rule    string   `json:"Rule"`
+	Matched bool     `json:"Matched"`
+	Changes []Change `json:"Changes,omitempty"`
+	Flag    string   `json:"Flag,omitempty"`
+}
+
+// assignment is a compiled Set entry.
+type assignment struct {
+	field   string
+	value   func(shot *Shot) *float64
+	program *vm.Program
+}
+
+// CompiledRule is a rule whose expressions have been checked and compiled.
+type CompiledRule struct {
+	Rule
+	when        *vm.Program
+	assignments []assignment
+}
+
+// Compile checks a rule and compiles its expressions.
+func Compile(rule Rule) (*CompiledRule, error) {
+	if rule.Name == "" {
+		return nil, fmt.Errorf("rule has no name")
+	}
+	if strings.TrimSpace(rule.When) == "" {
+		return nil, fmt.Errorf("rule %s has no condition", rule.Name)
+	}
+	if len(rule.Set) == 0 && rule.Flag == "" {
+		return nil, fmt.Errorf("rule %s neither sets a field nor adds a flag", rule.Name)
+	}
+
+	when, err := expr.Compile(rule.When, expr.Env(env{}), expr.AsBool())
+	if err != nil {
+		return nil, fmt.Errorf("rule %s condition: %w", rule.Name, err)
+	}
+	compiled := &CompiledRule{Rule: rule, when: when}
+
+	// Assignments run in field order so a rule behaves the same however its file lists them.
+	fields := make([]string, 0, len(rule.Set))
+	for field := range rule.Set {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		value, name, ok := fieldOf(field)
+		if !ok {
+			return nil, fmt.Errorf("rule %s sets unknown field %s", rule.Name, field)
+		}
+		program, err := expr.Compile(rule.Set[field], expr.Env(env{}), expr.AsFloat64())
+		if err != nil {
+			return nil, fmt.Errorf("rule %s value of %s: %w", rule.Name, field, err)
+		}
+		compiled.assignments = append(compiled.assignments, assignment{field: name, value: value, program: program})
+	}
+	return compiled, nil
+}
+
+// fieldOf finds the shot field a Set entry names, ignoring case, and returns its canonical name.
+func fieldOf(name string) (func(shot *Shot) *float64, string, bool) {
+	if club, ok := cutPrefixFold(name, "Club."); ok {
+		for _, f := range Shared.ClubFields {
+			if strings.EqualFold(f.Name, club) {
+				return func(shot *Shot) *float64 { return f.Value(&shot.Club) }, "Club." + f.Name, true
+			}
+		}
+		return nil, "", false
+	}
+	for _, f := range Shared.BallFields {
+		if strings.EqualFold(f.Name, name) {
+			return func(shot *Shot) *float64 { return f.Value(&shot.Ball) }, f.Name, true
+		}
+	}
+	return nil, "", false
+}
+
+func cutPrefixFold(s, prefix string) (string, bool) {
+	if len(s) >= len(prefix) && strings.EqualFold(s[:len(prefix)], prefix) {
+		return s[len(prefix):], true
+	}
+	return s, false
+}
+
+// Apply evaluates the rule against a shot and, when it matches, changes and flags the shot.
+// Every value is computed from the shot as it was before the rule, then assigned.
+func (r *CompiledRule) Apply(shot *Shot) (Outcome, error) {
+	outcome := Outcome{Rule: r.Name}
+	e := envOf(shot)
+	matched, err := expr.Run(r.when, e)
+	if err != nil {
+		return outcome, fmt.Errorf("rule %s condition: %w", r.Name, err)
+	}
+	if !matched.(bool) {
+		return outcome, nil
+	}
+	outcome.Matched = true
+
+	values := make([]float64, len(r.assignments))
+	for i, a := range r.assignments {
+		value, err := expr.Run(a.program, e)
+		if err != nil {
+			return outcome, fmt.Errorf("rule %s value of %s: %w", r.Name, a.field, err)
+		}
+		values[i] = value.(float64)
+		if math.IsNaN(values[i]) || math.IsInf(values[i], 0) {
+			return outcome, fmt.Errorf("rule %s value of %s is %v", r.Name, a.field, values[i])
+		}
+	}
+	for i, a := range r.assignments {
+		field := a.value(shot)
+		outcome.Changes = append(outcome.Changes, Change{Field: a.field, From: *field, To: values[i]})
+		*field = values[i]
+	}
+	if r.Flag != "" {
+		shot.Flags = append(shot.Flags, r.Flag)
+		outcome.Flag = r.Flag
+	}
+	return outcome, nil
+}
@@ -0,0 +1,66 @@
+package Shared
+
+// ClubType represents the type of golf club.
+type ClubType string
+
+const (
+	Driver        ClubType = "Driver"
+	ThreeWood     ClubType = "3Wood"
+	FiveWood      ClubType = "5Wood"
+	SevenWood     ClubType = "7Wood"
+	TwoHybrid     ClubType = "2Hybrid"
+	ThreeHybrid   ClubType = "3Hybrid"
+	FourHybrid    ClubType = "4Hybrid"
+	FiveHybrid    ClubType = "5Hybrid"
+	SixHybrid     ClubType = "6Hybrid"
+	OneIron       ClubType = "1Iron"
+	TwoIron       ClubType = "2Iron"
+	ThreeIron     ClubType = "3Iron"
+	FourIron      ClubType = "4Iron"
+	FiveIron      ClubType = "5Iron"
+	SixIron       ClubType = "6Iron"
+	SevenIron     ClubType = "7Iron"
+	EightIron     ClubType = "8Iron"
+	NineIron      ClubType = "9Iron"
+	PitchingWedge ClubType = "PitchingWedge"
+	GapWedge      ClubType = "GapWedge"
+	SandWedge     ClubType = "SandWedge"
+	LobWedge      ClubType = "LobWedge"
+	Putter        ClubType = "Putter"
+)
+
+// ValidClubTypes is a set of known valid club types
+var ValidClubTypes = map[ClubType]bool{
+	Driver:        true,
+	ThreeWood:     true,
+	FiveWood:      true,
+	SevenWood:     true,
+	TwoHybrid:     true,
+	ThreeHybrid:   true,
+	FourHybrid:    true,
+	FiveHybrid:    true,
+	SixHybrid:     true,
+	OneIron:       true,
+	TwoIron:       true,
+	ThreeIron:     true,
+	FourIron:      true,
+	FiveIron:      true,
+	SixIron:       true,
+	SevenIron:     true,
+	EightIron:     true,
+	NineIron:      true,
+	PitchingWedge: true,
+	GapWedge:      true,
+	SandWedge:     true,
+	LobWedge:      true,
+	Putter:        true,
+}
+
+// ClubTypes lists the known club types in bag order, from longest to shortest.
+var ClubTypes = []ClubType{
+	Driver, ThreeWood, FiveWood, SevenWood,
+	TwoHybrid, ThreeHybrid, FourHybrid, FiveHybrid, SixHybrid,
+	OneIron, TwoIron, ThreeIron, FourIron, FiveIron, SixIron, SevenIron, EightIron, NineIron,
+	PitchingWedge, GapWedge, SandWedge, LobWedge,
+	Putter,
+}
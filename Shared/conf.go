@@ -19,13 +19,30 @@ type Camera struct {
 }
 
 type LaunchMonitor struct {
-	Name string
+	Name  string
+	Names []string
+}
+
+// Uses reports whether the named launch monitor is configured, on its own or as part of a fusion.
+func (lm LaunchMonitor) Uses(name string) bool {
+	for _, n := range lm.Names {
+		if n == name {
+			return true
+		}
+	}
+	return false
 }
 
 type Simulator struct {
-	Name      string
-	IPAddress string
-	Port      int
+	Name                string
+	IPAddress           string
+	Port                int
+	ReconnectMaxSeconds int
+	QueueMaxAgeSeconds  int
+	QueueFile           string
+	AckTimeoutSeconds   int
+	TargetsFile         string
+	Units               UnitSystem
 }
 
 type Bridge struct {
@@ -42,20 +59,117 @@ type HTTP struct {
 	Port      int
 }
 
+type Display struct {
+	Units UnitSystem
+}
+
+type Golfer struct {
+	Handed Handedness
+}
+
+type Virtual struct {
+	Scenario string
+	Skill    string
+	Tendency string
+	Prompt   bool
+}
+
+type VirtualSimulator struct {
+	Ground    string
+	SpinDecay float64
+}
+
+type Webhook struct {
+	URL            string
+	TemplateFile   string
+	Headers        string
+	TimeoutSeconds int
+	Retries        int
+	Secret         string
+}
+
+type Plugin struct {
+	SimulatorCommand     string
+	LaunchMonitorCommand string
+}
+
+type Putting struct {
+	Device string
+	Auto   bool
+}
+
+type Replay struct {
+	File         string
+	Mode         string
+	Data         string
+	DelaySeconds float64
+}
+
+type JSON struct {
+	Protocol string
+	Port     int
+}
+
+type OpenConnect struct {
+	Port int
+}
+
+type Fusion struct {
+	WindowMilliseconds int
+	Policy             string
+}
+
+type Serial struct {
+	Device      string
+	Baud        int
+	Framing     string
+	LengthBytes int
+}
+
+type Mapping struct {
+	File string
+}
+
+type Pipeline struct {
+	Stages     string
+	RulesFile  string
+	BoundsFile string
+}
+
 type Config struct {
 	LaunchMonitor
 	Simulator
 	Bridge
 	Camera
 	HTTP
+	Display
+	Golfer
+	Virtual
+	VirtualSimulator
+	Webhook
+	Plugin
+	Putting
+	Replay
+	JSON
+	OpenConnect
+	Serial
+	Fusion
+	Mapping
+	Pipeline
 }
 
 // ParseFlags parses command-line flags and returns a Config struct.
 func ParseFlags() Config {
-	launchMonitor := flag.String("launch-monitor", "", "Name of the launch monitor (required)")
+	launchMonitor := flag.String("launch-monitor", "", "Name of the launch monitor, or a comma-separated list to fuse several (required)")
 	simulator := flag.String("simulator", "", "Name of the simulator (required)")
 	simIP := flag.String("simulator-ip", "127.0.0.1", "IP address for the simulator")
 	simPort := flag.Int("simulator-port", 921, "Port for the simulator")
+	simReconnectMax := flag.Int("simulator-reconnect-max-seconds", 30, "Longest delay between attempts to reconnect to the simulator")
+	simQueueMaxAge := flag.Int("simulator-queue-max-age-seconds", 300, "Drop shots queued while the simulator is disconnected after this many seconds (0 keeps them)")
+	simAckTimeout := flag.Int("simulator-ack-timeout-seconds", 5, "How long to wait for the simulator to acknowledge a shot (0 does not wait)")
+	simTargetsFile := flag.String("simulator-targets-file", "", "YAML or JSON file listing the simulators that receive every shot when -simulator is Fanout")
+	simQueueFile := flag.String("simulator-queue-file", "", "File that keeps queued shots across restarts (empty keeps them in memory only)")
+	simUnits := flag.String("simulator-units", "", "Units shots are sent to the simulator in (imperial, metric; defaults to -units)")
 	bridgeIP := flag.String("bridge-ip", "127.0.0.1", "IP address for the Fairway Bridge")
 	bridgePort := flag.Int("bridge-port", 2483, "Port for the Fairway Bridge")
 	httpIP := flag.String("http-ip", "127.0.0.1", "IP address for the Fairway Bridge HTTP Server")
@@ -68,6 +182,41 @@ func ParseFlags() Config {
 	autoStopSeconds := flag.Int("camera-auto-stop-seconds", 5, "Recording duration (in seconds) before auto-stop")
 	overrideVideo := flag.Bool("camera-override-video", false, "If true, always save to the same video file instead of creating new ones")
 	networkIP := flag.String("camera-network-ip", "10.5.5.100", "Local IP address to use for outbound connections")
+	units := flag.String("units", "IMPERIAL", "Units for stored shots, the HTTP API and the web pages (imperial, metric)")
+	handed := flag.String("golfer-handed", "RH", "Handedness of the golfer (RH, LH)")
+	virtualScenario := flag.String("virtual-scenario", "", "YAML or JSON scenario file for the virtual launch monitor")
+	virtualPrompt := flag.Bool("virtual-prompt", true, "Prompt for target distances on standard input (disable when running as a service)")
+	virtualSkill := flag.String("virtual-skill", "SCRATCH", "Skill level of generated virtual shots (tour, scratch, average, beginner)")
+	virtualTendency := flag.String("virtual-tendency", "STRAIGHT", "Shot shape tendency of generated virtual shots (straight, hook, slice, push, pull)")
+	virtualSimGround := flag.String("virtual-simulator-ground", "NORMAL", "How far shots bounce and roll out on the virtual simulator (none, soft, normal, firm)")
+	virtualSimSpinDecay := flag.Float64("virtual-simulator-spin-decay", 0.04, "Share of the ball's spin lost per second of flight on the virtual simulator")
+	webhookURL := flag.String("webhook-url", "", "URL each shot is POSTed to when -simulator is Webhook")
+	webhookTemplate := flag.String("webhook-template-file", "", "Go text/template file for the webhook request body (defaults to the shot as JSON)")
+	webhookHeaders := flag.String("webhook-headers", "", "Extra webhook request headers (e.g. Authorization=Bearer abc,X-Source=bay1)")
+	webhookTimeout := flag.Int("webhook-timeout-seconds", 5, "How long to wait for the webhook to answer each request")
+	webhookRetries := flag.Int("webhook-retries", 2, "How many times to retry a webhook request after a network error, 429 or 5xx response")
+	webhookSecret := flag.String("webhook-secret", "", "Secret used to sign webhook request bodies with HMAC-SHA256 (empty does not sign)")
+	simulatorPlugin := flag.String("simulator-plugin", "", "Command line of the plugin that receives shots when -simulator is Plugin (e.g. \"python3 my_sim.py\")")
+	launchMonitorPlugin := flag.String("launch-monitor-plugin", "", "Command line of the plugin that reports shots when -launch-monitor is Plugin")
+	puttingDevice := flag.String("putting-device", "", "Launch monitor that only reads putts, e.g. JSON or Serial (empty takes putts from the HTTP API only)")
+	puttingAuto := flag.Bool("putting-auto", true, "Turn putting mode on when the simulator reports the ball is on the green, and off when it leaves")
+	replayFile := flag.String("replay-file", "", "Shot file to replay, other than -bridge-shot-file (required for Replay)")
+	replayMode := flag.String("replay-mode", "DELAY", "Replay timing (delay, original, manual)")
+	replayData := flag.String("replay-data", "RAW", "Shot data to replay (raw, adjusted)")
+	replayDelay := flag.Float64("replay-delay-seconds", 5, "Delay (in seconds) between shots in delay mode")
+	jsonProtocol := flag.String("json-protocol", "TCP", "Transport the JSON launch monitor listens on (tcp, udp)")
+	jsonPort := flag.Int("json-port", 0, "Port for the JSON launch monitor (defaults to -bridge-port)")
+	openConnectPort := flag.Int("openconnect-port", 0, "Port for the GSPro Open Connect launch monitor (defaults to -bridge-port)")
+	fusionWindow := flag.Int("fusion-window-ms", 500, "Time window (in milliseconds) in which shots from fused launch monitors are merged")
+	fusionPolicy := flag.String("fusion-policy", "default=first", "How fused shots are merged per field (e.g. spin=R10,speed=average,default=first)")
+	serialDevice := flag.String("serial-device", "", "Serial device path for the serial launch monitor (e.g. /dev/ttyUSB0)")
+	serialBaud := flag.Int("serial-baud", 115200, "Baud rate of the serial device")
+	serialFraming := flag.String("serial-framing", "LINE", "How serial messages are delimited (line, length)")
+	serialLengthBytes := flag.Int("serial-length-bytes", 2, "Size in bytes of the big-endian length prefix for length framing (1, 2, 4)")
+	pipeline := flag.String("pipeline", "convert,enrich,validate,adjust,rules,route,persist,capture", "Ordered shot-processing stages, each optionally with =abort or =continue to set its error policy")
+	rulesFile := flag.String("rules-file", "", "YAML or JSON file of conditional rules applied to shots by the rules stage, reloaded when it changes")
+	boundsFile := flag.String("validation-bounds-file", "", "YAML or JSON file of plausibility bounds, per club, outside which shots are held in quarantine")
+	mappingFile := flag.String("mapping-file", "", "YAML or JSON file mapping device fields to shot data for the JSON and serial launch monitors")
 
 	// Parse all flags.
 	flag.Parse()
@@ -79,15 +228,45 @@ func ParseFlags() Config {
 		os.Exit(1)
 	}
 
+	// Check the unit systems; the simulator uses the display units unless told otherwise.
+	if *simUnits == "" {
+		*simUnits = *units
+	}
+	displayUnits, err := ParseUnitSystem(*units)
+	if err != nil {
+		fmt.Printf("Error: -units: %v\n", err)
+		os.Exit(1)
+	}
+	simulatorUnits, err := ParseUnitSystem(*simUnits)
+	if err != nil {
+		fmt.Printf("Error: -simulator-units: %v\n", err)
+		os.Exit(1)
+	}
+
+	// The JSON and Open Connect launch monitors listen on the bridge port unless given their own.
+	if *jsonPort == 0 {
+		*jsonPort = *bridgePort
+	}
+	if *openConnectPort == 0 {
+		*openConnectPort = *bridgePort
+	}
+
 	// Build the configuration from the provided flags.
-	return Config{
+	config := Config{
 		LaunchMonitor: LaunchMonitor{
-			Name: strings.ToUpper(*launchMonitor),
+			Name:  strings.ToUpper(*launchMonitor),
+			Names: parseNames(*launchMonitor),
 		},
 		Simulator: Simulator{
-			Name:      strings.ToUpper(*simulator),
-			IPAddress: *simIP,
-			Port:      *simPort,
+			Name:                strings.ToUpper(*simulator),
+			IPAddress:           *simIP,
+			Port:                *simPort,
+			ReconnectMaxSeconds: *simReconnectMax,
+			QueueMaxAgeSeconds:  *simQueueMaxAge,
+			QueueFile:           *simQueueFile,
+			AckTimeoutSeconds:   *simAckTimeout,
+			TargetsFile:         *simTargetsFile,
+			Units:               simulatorUnits,
 		},
 		Bridge: Bridge{
 			IPAddress: *bridgeIP,
@@ -108,7 +287,134 @@ func ParseFlags() Config {
 			IPAddress: *httpIP,
 			Port:      *httpPort,
 		},
+		Display: Display{
+			Units: displayUnits,
+		},
+		Golfer: Golfer{
+			Handed: ParseHandedness(*handed),
+		},
+		Virtual: Virtual{
+			Scenario: *virtualScenario,
+			Skill:    strings.ToUpper(*virtualSkill),
+			Tendency: strings.ToUpper(*virtualTendency),
+			Prompt:   *virtualPrompt && *virtualScenario == "",
+		},
+		VirtualSimulator: VirtualSimulator{
+			Ground:    strings.ToUpper(*virtualSimGround),
+			SpinDecay: *virtualSimSpinDecay,
+		},
+		Webhook: Webhook{
+			URL:            *webhookURL,
+			TemplateFile:   *webhookTemplate,
+			Headers:        *webhookHeaders,
+			TimeoutSeconds: *webhookTimeout,
+			Retries:        *webhookRetries,
+			Secret:         *webhookSecret,
+		},
+		Plugin: Plugin{
+			SimulatorCommand:     *simulatorPlugin,
+			LaunchMonitorCommand: *launchMonitorPlugin,
+		},
+		Putting: Putting{
+			Device: strings.ToUpper(*puttingDevice),
+			Auto:   *puttingAuto,
+		},
+		Replay: Replay{
+			File:         *replayFile,
+			Mode:         strings.ToUpper(*replayMode),
+			Data:         strings.ToUpper(*replayData),
+			DelaySeconds: *replayDelay,
+		},
+		JSON: JSON{
+			Protocol: strings.ToUpper(*jsonProtocol),
+			Port:     *jsonPort,
+		},
+		OpenConnect: OpenConnect{
+			Port: *openConnectPort,
+		},
+		Fusion: Fusion{
+			WindowMilliseconds: *fusionWindow,
+			Policy:             *fusionPolicy,
+		},
+		Serial: Serial{
+			Device:      *serialDevice,
+			Baud:        *serialBaud,
+			Framing:     strings.ToUpper(*serialFraming),
+			LengthBytes: *serialLengthBytes,
+		},
+		Mapping: Mapping{
+			File: *mappingFile,
+		},
+		Pipeline: Pipeline{
+			Stages:     *pipeline,
+			RulesFile:  *rulesFile,
+			BoundsFile: *boundsFile,
+		},
+	}
+
+	if err := checkListeners(config); err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
 	}
+	return config
+}
+
+// checkListeners checks that no two of the HTTP server and the launch monitors that accept connections,
+// including the putting device, listen on the same address, which would only fail once the second one binds.
+func checkListeners(config Config) error {
+	type listener struct {
+		protocol string
+		address  string
+	}
+	owners := map[listener]string{
+		{"TCP", fmt.Sprintf("%s:%d", config.HTTP.IPAddress, config.HTTP.Port)}: "the HTTP server (-http-port)",
+	}
+
+	names := config.LaunchMonitor.Names
+	if config.Putting.Device != "" {
+		names = append(append([]string{}, names...), config.Putting.Device)
+	}
+	for _, name := range names {
+		var l listener
+		var owner string
+		switch name {
+		case "R10":
+			l, owner = listener{"TCP", fmt.Sprintf("%s:%d", config.Bridge.IPAddress, config.Bridge.Port)}, "R10 (-bridge-port)"
+		case "OPENCONNECT":
+			l, owner = listener{"TCP", fmt.Sprintf("%s:%d", config.Bridge.IPAddress, config.OpenConnect.Port)}, "OpenConnect (-openconnect-port)"
+		case "JSON":
+			l, owner = listener{config.JSON.Protocol, fmt.Sprintf("%s:%d", config.Bridge.IPAddress, config.JSON.Port)}, "JSON (-json-port)"
+		default:
+			continue
+		}
+		if other, ok := owners[l]; ok {
+			return fmt.Errorf("%s and %s both listen on %s %s, give one of them its own port", other, owner, l.protocol, l.address)
+		}
+		owners[l] = owner
+	}
+	return nil
+}
+
+// parseNames splits a comma-separated list of launch monitor names.
+func parseNames(value string) []string {
+	var names []string
+	for _, name := range strings.Split(value, ",") {
+		if name = strings.ToUpper(strings.TrimSpace(name)); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// headerNames lists the names in a comma-separated list of Name=Value headers.
+func headerNames(headers string) string {
+	var names []string
+	for _, pair := range strings.Split(headers, ",") {
+		if name, _, _ := strings.Cut(pair, "="); strings.TrimSpace(name) != "" {
+			names = append(names, strings.TrimSpace(name))
+		}
+	}
+	return strings.Join(names, ", ")
 }
 
 // PrintConfig prints the configuration to the provided logger.
@@ -118,12 +424,57 @@ func (config *Config) PrintConfig(logger *zap.Logger) {
 	}
 	log := logger.With(zap.String("component", "CONFIG")).Sugar()
 	log.Infof("Launch Monitor:\n  - Name: %s\n", config.LaunchMonitor.Name)
-	log.Infof("Simulator:\n  - Name: %s\n  - IP Address: %s\n  - Port: %d\n",
-		config.Simulator.Name, config.Simulator.IPAddress, config.Simulator.Port)
+	log.Infof("Simulator:\n  - Name: %s\n  - IP Address: %s\n  - Port: %d\n  - Reconnect Max Seconds: %d\n  - Queue Max Age Seconds: %d\n  - Queue File: %s\n  - Ack Timeout Seconds: %d\n  - Units: %s\n",
+		config.Simulator.Name, config.Simulator.IPAddress, config.Simulator.Port,
+		config.Simulator.ReconnectMaxSeconds, config.Simulator.QueueMaxAgeSeconds, config.Simulator.QueueFile, config.Simulator.AckTimeoutSeconds,
+		config.Simulator.Units)
 	log.Infof("Fairway Bridge:\n  - IP Address: %s\n  - Port: %d\n  - Log File: %s\n  - Shot File: %s\n  - Version: %s\n",
 		config.Bridge.IPAddress, config.Bridge.Port, config.Bridge.LogFile, config.Bridge.ShotFile, config.Bridge.Version)
 	log.Infof("HTTP Server:\n  - IP Address: %s\n  - Port: %d\n",
 		config.HTTP.IPAddress, config.HTTP.Port)
 	log.Infof("Camera:\n  - Name: %s\n  - Video Directory: %s\n  - Auto Stop (s): %d\n  - Override Video: %t\n  - Network IP: %s\n",
 		config.Camera.Name, config.Camera.VideoDir, config.Camera.AutoStopSeconds, config.Camera.OverrideVideo, config.Camera.NetworkIP)
+	log.Infof("Display:\n  - Units: %s\n", config.Display.Units)
+	log.Infof("Pipeline:\n  - Stages: %s\n  - Rules File: %s\n  - Validation Bounds File: %s\n",
+		config.Pipeline.Stages, config.Pipeline.RulesFile, config.Pipeline.BoundsFile)
+	log.Infof("Golfer:\n  - Handed: %s\n", config.Golfer.Handed)
+	if config.Simulator.Name == "FANOUT" {
+		log.Infof("Simulator Fan-out:\n  - Targets File: %s\n", config.Simulator.TargetsFile)
+	}
+	if config.Simulator.Name == "VIRTUAL" {
+		log.Infof("Virtual Simulator:\n  - Ground: %s\n  - Spin Decay: %.3f\n", config.VirtualSimulator.Ground, config.VirtualSimulator.SpinDecay)
+	}
+	if config.Simulator.Name == "WEBHOOK" {
+		// Header values and the secret are left out as they usually hold credentials.
+		log.Infof("Webhook:\n  - URL: %s\n  - Template File: %s\n  - Headers: %s\n  - Timeout Seconds: %d\n  - Retries: %d\n  - Signed: %t\n",
+			config.Webhook.URL, config.Webhook.TemplateFile, headerNames(config.Webhook.Headers),
+			config.Webhook.TimeoutSeconds, config.Webhook.Retries, config.Webhook.Secret != "")
+	}
+	if config.Simulator.Name == "PLUGIN" || config.LaunchMonitor.Uses("PLUGIN") {
+		log.Infof("Plugins:\n  - Simulator: %s\n  - Launch Monitor: %s\n", config.Plugin.SimulatorCommand, config.Plugin.LaunchMonitorCommand)
+	}
+	log.Infof("Putting:\n  - Device: %s\n  - Auto: %t\n", config.Putting.Device, config.Putting.Auto)
+	if len(config.LaunchMonitor.Names) > 1 {
+		log.Infof("Fusion:\n  - Launch Monitors: %s\n  - Window (ms): %d\n  - Policy: %s\n",
+			strings.Join(config.LaunchMonitor.Names, ", "), config.Fusion.WindowMilliseconds, config.Fusion.Policy)
+	}
+	if config.LaunchMonitor.Uses("VIRTUAL") {
+		log.Infof("Virtual Launch Monitor:\n  - Scenario: %s\n  - Skill: %s\n  - Tendency: %s\n  - Prompt: %t\n",
+			config.Virtual.Scenario, config.Virtual.Skill, config.Virtual.Tendency, config.Virtual.Prompt)
+	}
+	if config.LaunchMonitor.Uses("REPLAY") {
+		log.Infof("Replay:\n  - File: %s\n  - Mode: %s\n  - Data: %s\n  - Delay (s): %.1f\n",
+			config.Replay.File, config.Replay.Mode, config.Replay.Data, config.Replay.DelaySeconds)
+	}
+	if config.LaunchMonitor.Uses("JSON") {
+		log.Infof("JSON Launch Monitor:\n  - Protocol: %s\n  - Port: %d\n  - Mapping File: %s\n",
+			config.JSON.Protocol, config.JSON.Port, config.Mapping.File)
+	}
+	if config.LaunchMonitor.Uses("OPENCONNECT") {
+		log.Infof("Open Connect Launch Monitor:\n  - Port: %d\n", config.OpenConnect.Port)
+	}
+	if config.LaunchMonitor.Uses("SERIAL") {
+		log.Infof("Serial Launch Monitor:\n  - Device: %s\n  - Baud: %d\n  - Framing: %s\n  - Length Bytes: %d\n  - Mapping File: %s\n",
+			config.Serial.Device, config.Serial.Baud, config.Serial.Framing, config.Serial.LengthBytes, config.Mapping.File)
+	}
 }
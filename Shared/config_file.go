@@ -0,0 +1,35 @@
+package Shared
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ReadConfigFile decodes a YAML or JSON file into v, choosing the format from the file's extension.
+// YAML is decoded generically and converted to JSON so both formats share the JSON field names.
+func ReadConfigFile(path string, v any) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	ext := strings.ToLower(filepath.Ext(path))
+	if ext == ".yaml" || ext == ".yml" {
+		var generic interface{}
+		if err := yaml.Unmarshal(data, &generic); err != nil {
+			return fmt.Errorf("parsing %s: %w", path, err)
+		}
+		if data, err = json.Marshal(generic); err != nil {
+			return fmt.Errorf("parsing %s: %w", path, err)
+		}
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("parsing %s: %w", path, err)
+	}
+	return nil
+}
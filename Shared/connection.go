@@ -0,0 +1,22 @@
+package Shared
+
+import "time"
+
+// ConnectionState describes the state of a connection to a simulator or device.
+type ConnectionState string
+
+const (
+	Connecting   ConnectionState = "CONNECTING"
+	Connected    ConnectionState = "CONNECTED"
+	Disconnected ConnectionState = "DISCONNECTED"
+)
+
+// ConnectionStatus reports the state of a connection and the shots waiting to be sent over it.
+type ConnectionStatus struct {
+	State       ConnectionState `json:"State"`
+	Since       time.Time       `json:"Since"`
+	QueuedShots int             `json:"QueuedShots"`
+}
+
+// ConnectionHandlerFunc defines a callback function type that handles connection state changes.
+type ConnectionHandlerFunc func(status ConnectionStatus)
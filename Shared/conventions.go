@@ -0,0 +1,157 @@
+package Shared
+
+import (
+	"math"
+	"strings"
+	"sync"
+)
+
+// SpinAxisRange describes the range an adapter uses to express the spin axis.
+type SpinAxisRange int
+
+const (
+	// SpinAxisSigned expresses the spin axis from -180° to 180°.
+	SpinAxisSigned SpinAxisRange = iota
+	// SpinAxisUnsigned expresses the spin axis from 0° to 360°.
+	SpinAxisUnsigned
+)
+
+// Direction describes which side of the target line a positive value points to.
+type Direction float64
+
+const (
+	RightPositive Direction = 1
+	LeftPositive  Direction = -1
+)
+
+// Framing describes the frame horizontal values are reported in.
+type Framing int
+
+const (
+	// TargetFraming reports values as seen from behind the ball looking at the target, for every golfer.
+	TargetFraming Framing = iota
+	// GolferFraming reports values relative to the golfer, so they are mirrored for left-handed golfers.
+	GolferFraming
+)
+
+// Handedness describes which side of the ball the golfer stands on.
+type Handedness string
+
+const (
+	RightHanded Handedness = "RH"
+	LeftHanded  Handedness = "LH"
+)
+
+// Convention describes how an adapter expresses directional shot data.
+// HLADirection also applies to the horizontal club angles (Path and FaceToTarget).
+type Convention struct {
+	SpinAxisRange     SpinAxisRange
+	SpinAxisDirection Direction
+	HLADirection      Direction
+	Framing           Framing
+}
+
+// StandardConvention is the convention used by StandardizedBallData and StandardizedClubData.
+// The spin axis ranges from -180° to 180°, and a positive spin axis, HLA, path or face angle
+// points right of the target line regardless of the golfer's handedness.
+var StandardConvention = Convention{
+	SpinAxisRange:     SpinAxisSigned,
+	SpinAxisDirection: RightPositive,
+	HLADirection:      RightPositive,
+	Framing:           TargetFraming,
+}
+
+var (
+	handedness      = RightHanded
+	handednessMutex sync.RWMutex
+)
+
+// GetHandedness returns the handedness of the current golfer.
+func GetHandedness() Handedness {
+	handednessMutex.RLock()
+	defer handednessMutex.RUnlock()
+	return handedness
+}
+
+// SetHandedness sets the handedness of the current golfer.
+func SetHandedness(h Handedness) {
+	handednessMutex.Lock()
+	defer handednessMutex.Unlock()
+	handedness = h
+}
+
+// ParseHandedness parses a handedness value such as "RH" or "LH", defaulting to right-handed.
+func ParseHandedness(value string) Handedness {
+	if strings.ToUpper(value) == string(LeftHanded) {
+		return LeftHanded
+	}
+	return RightHanded
+}
+
+// spinAxisSign returns the factor that maps a convention's spin axis to a right-positive target frame.
+func (c Convention) spinAxisSign(handed Handedness) float64 {
+	sign := float64(c.SpinAxisDirection)
+	if c.Framing == GolferFraming && handed == LeftHanded {
+		sign = -sign
+	}
+	return sign
+}
+
+// horizontalSign returns the factor that maps a convention's horizontal angles to a right-positive target frame.
+func (c Convention) horizontalSign(handed Handedness) float64 {
+	sign := float64(c.HLADirection)
+	if c.Framing == GolferFraming && handed == LeftHanded {
+		sign = -sign
+	}
+	return sign
+}
+
+// normalizeSpinAxis expresses a spin axis in the given range.
+func normalizeSpinAxis(axis float64, r SpinAxisRange) float64 {
+	axis = math.Mod(axis, 360)
+	switch r {
+	case SpinAxisUnsigned:
+		if axis < 0 {
+			axis += 360
+		}
+	default:
+		if axis > 180 {
+			axis -= 360
+		} else if axis <= -180 {
+			axis += 360
+		}
+	}
+	return axis
+}
+
+// ConvertBallData converts ball data from one convention to another for a golfer with the given handedness.
+func ConvertBallData(ball StandardizedBallData, from Convention, to Convention, handed Handedness) StandardizedBallData {
+	spinSign := from.spinAxisSign(handed) * to.spinAxisSign(handed)
+	hlaSign := from.horizontalSign(handed) * to.horizontalSign(handed)
+
+	converted := ball
+	converted.SpinAxis = normalizeSpinAxis(normalizeSpinAxis(ball.SpinAxis, SpinAxisSigned)*spinSign, to.SpinAxisRange)
+	converted.SideSpin = ball.SideSpin * spinSign
+	converted.HLA = ball.HLA * hlaSign
+	return converted
+}
+
+// ConvertClubData converts club data from one convention to another for a golfer with the given handedness.
+func ConvertClubData(club StandardizedClubData, from Convention, to Convention, handed Handedness) StandardizedClubData {
+	hlaSign := from.horizontalSign(handed) * to.horizontalSign(handed)
+
+	converted := club
+	converted.Path = club.Path * hlaSign
+	converted.FaceToTarget = club.FaceToTarget * hlaSign
+	return converted
+}
+
+// ConvertShotResult converts the deviations of a shot result from one convention to another.
+func ConvertShotResult(result ShotResult, from Convention, to Convention, handed Handedness) ShotResult {
+	hlaSign := from.horizontalSign(handed) * to.horizontalSign(handed)
+
+	converted := result
+	converted.CarryDeviation = result.CarryDeviation * hlaSign
+	converted.TotalDeviation = result.TotalDeviation * hlaSign
+	return converted
+}
@@ -0,0 +1,72 @@
+package Shared
+
+import (
+	"sync"
+	"time"
+)
+
+// DeliveryStatus describes whether a shot reached the simulator.
+type DeliveryStatus string
+
+const (
+	// Delivered means the simulator accepted the shot.
+	Delivered DeliveryStatus = "DELIVERED"
+	// Queued means the simulator was disconnected and the shot will be sent when it reconnects.
+	Queued DeliveryStatus = "QUEUED"
+	// Rejected means the simulator answered with an error, e.g. when no player is in a round.
+	Rejected DeliveryStatus = "REJECTED"
+	// TimedOut means the shot was sent but the simulator did not answer in time.
+	TimedOut DeliveryStatus = "TIMED_OUT"
+	// Failed means the shot could not be sent.
+	Failed DeliveryStatus = "FAILED"
+)
+
+// Delivery is the outcome of sending a shot to the simulator.
+type Delivery struct {
+	Status  DeliveryStatus `json:"Status"`
+	Message string         `json:"Message,omitempty"`
+}
+
+// RecentShot is a shot kept in the recent shot history for the UI.
+type RecentShot struct {
+	Timestamp time.Time            `json:"Timestamp"`
+	ClubType  string               `json:"ClubType"`
+	Ball      StandardizedBallData `json:"Ball"`
+	Club      StandardizedClubData `json:"Club"`
+	Delivery  Delivery             `json:"Delivery"`
+	Result    *ShotResult          `json:"Result,omitempty"`
+	Metrics   map[string]float64   `json:"Metrics,omitempty"`
+	Flags     []string             `json:"Flags,omitempty"`
+	// RulesBall and RulesClub are the shot before any rule changed it, which rules are dry-run against.
+	RulesBall StandardizedBallData `json:"-"`
+	RulesClub StandardizedClubData `json:"-"`
+}
+
+// recentShotLimit is the number of shots kept in the recent shot history.
+const recentShotLimit = 20
+
+var (
+	recentShots      []RecentShot
+	recentShotsMutex sync.RWMutex
+)
+
+// AddRecentShot adds a shot to the recent shot history, dropping the oldest when it is full.
+func AddRecentShot(shot RecentShot) {
+	recentShotsMutex.Lock()
+	defer recentShotsMutex.Unlock()
+	recentShots = append(recentShots, shot)
+	if len(recentShots) > recentShotLimit {
+		recentShots = recentShots[len(recentShots)-recentShotLimit:]
+	}
+}
+
+// GetRecentShots returns the recent shot history, newest first.
+func GetRecentShots() []RecentShot {
+	recentShotsMutex.RLock()
+	defer recentShotsMutex.RUnlock()
+	shots := make([]RecentShot, len(recentShots))
+	for i, shot := range recentShots {
+		shots[len(recentShots)-1-i] = shot
+	}
+	return shots
+}
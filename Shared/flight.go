@@ -0,0 +1,194 @@
+package Shared
+
+import (
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ShotResult provides a standardized structure for the outcome of a shot.
+// Distances and deviations are in yards, with positive deviations to the right of the target line.
+type ShotResult struct {
+	CarryDistance  float64 `json:"CarryDistance"`
+	TotalDistance  float64 `json:"TotalDistance"`
+	Apex           float64 `json:"Apex"`
+	CarryDeviation float64 `json:"CarryDeviation"`
+	TotalDeviation float64 `json:"TotalDeviation"`
+	DescentAngle   float64 `json:"DescentAngle"`
+	FlightTime     float64 `json:"FlightTime"`
+}
+
+const (
+	ballMass     = 0.04593  // kg
+	ballRadius   = 0.021335 // m
+	airDensity   = 1.225    // kg/m³
+	gravity      = 9.81     // m/s²
+	mphToMPS     = 0.44704
+	metersToYard = 1.0936133
+	flightStep   = 0.01 // s
+	maxFlightSec = 20.0
+
+	// spinBrake is the share of the ball's surface speed from backspin that checks it on a bounce.
+	spinBrake = 0.3
+	// minBounceSpeed is the vertical speed, in m/s, below which the ball stops bouncing and rolls.
+	minBounceSpeed = 1.0
+	maxBounces     = 10
+	// greenResistance is the rolling resistance of a green, as a share of gravity, close to a stimp of 10.
+	greenResistance = 0.065
+)
+
+// Ground describes how a ball bounces and rolls out after landing.
+type Ground struct {
+	// Restitution is the share of the vertical speed kept on each bounce.
+	Restitution float64
+	// Friction is the share of the horizontal speed kept on each bounce.
+	Friction float64
+	// RollingResistance is the rolling deceleration as a share of gravity; zero means the ball stops where it lands.
+	RollingResistance float64
+}
+
+// Grounds are the ground presets, from a ball that plugs where it lands to a firm summer fairway.
+var Grounds = map[string]Ground{
+	"NONE":   {},
+	"SOFT":   {Restitution: 0.25, Friction: 0.5, RollingResistance: 0.2},
+	"NORMAL": {Restitution: 0.35, Friction: 0.6, RollingResistance: 0.12},
+	"FIRM":   {Restitution: 0.45, Friction: 0.7, RollingResistance: 0.07},
+}
+
+// ParseGround returns the ground preset with the given name.
+func ParseGround(name string) (Ground, error) {
+	ground, ok := Grounds[strings.ToUpper(name)]
+	if !ok {
+		return Ground{}, fmt.Errorf("unknown ground %s (none, soft, normal, firm)", name)
+	}
+	return ground, nil
+}
+
+// FlightModel holds the parameters of the ball-flight model.
+type FlightModel struct {
+	// SpinDecay is the share of the spin lost per second of flight.
+	SpinDecay float64
+	Ground    Ground
+}
+
+// DefaultFlightModel is the flight model used to estimate shots when no simulator reports a result.
+var DefaultFlightModel = FlightModel{SpinDecay: 0.04, Ground: Grounds["NORMAL"]}
+
+// EstimateFlight estimates the outcome of a shot with the club from its ball data using the default flight model.
+func EstimateFlight(ball StandardizedBallData, club ClubType) ShotResult {
+	return DefaultFlightModel.Simulate(ball, club)
+}
+
+// Simulate computes the outcome of a shot from its ball data using a point-mass ball-flight model
+// with aerodynamic drag, Magnus lift and spin decay, followed by the ground's bounce and roll.
+// Putts, which are shots with the putter, roll on the green instead. Any other shot is flown, so a
+// ball launched level or into the ground lands at once and runs out on the ground.
+func (m FlightModel) Simulate(ball StandardizedBallData, club ClubType) ShotResult {
+	if ball.Speed <= 0 {
+		return ShotResult{}
+	}
+	if club == Putter {
+		return roll(ball)
+	}
+
+	area := math.Pi * ballRadius * ballRadius
+	vla := ball.VLA * math.Pi / 180
+	hla := ball.HLA * math.Pi / 180
+	axis := ball.SpinAxis * math.Pi / 180
+	omega := ball.TotalSpin * 2 * math.Pi / 60 // rad/s
+
+	// x is down the target line, y is up and z is to the right.
+	speed := ball.Speed * mphToMPS
+	vx := speed * math.Cos(vla) * math.Cos(hla)
+	vy := speed * math.Sin(vla)
+	vz := speed * math.Cos(vla) * math.Sin(hla)
+
+	// Backspin spins about the z axis; a positive spin axis tilts it so the ball curves right.
+	wx, wy, wz := 0.0, -math.Sin(axis), math.Cos(axis)
+
+	var x, y, z, apex, t float64
+	for t = 0; t < maxFlightSec; t += flightStep {
+		v := math.Sqrt(vx*vx + vy*vy + vz*vz)
+		spinRatio := omega * ballRadius / v
+		cd := 0.22 + 0.25*spinRatio
+		cl := math.Min(0.06+0.9*spinRatio, 0.35)
+		k := 0.5 * airDensity * area * v * v / ballMass
+
+		// Lift acts along ω × v, normalized.
+		lx := wy*vz - wz*vy
+		ly := wz*vx - wx*vz
+		lz := wx*vy - wy*vx
+		ln := math.Sqrt(lx*lx + ly*ly + lz*lz)
+		if ln > 0 {
+			lx, ly, lz = lx/ln, ly/ln, lz/ln
+		}
+
+		ax := -k*cd*vx/v + k*cl*lx
+		ay := -k*cd*vy/v + k*cl*ly - gravity
+		az := -k*cd*vz/v + k*cl*lz
+
+		vx += ax * flightStep
+		vy += ay * flightStep
+		vz += az * flightStep
+		x += vx * flightStep
+		y += vy * flightStep
+		z += vz * flightStep
+		omega *= 1 - m.SpinDecay*flightStep
+
+		if y > apex {
+			apex = y
+		}
+		if y < 0 {
+			break
+		}
+	}
+
+	horizontal := math.Sqrt(vx*vx + vz*vz)
+	descent := math.Atan2(-vy, horizontal) * 180 / math.Pi
+	carry := x * metersToYard
+	carryDeviation := z * metersToYard
+
+	roll := m.Ground.rollOut(horizontal, -vy, omega) * metersToYard
+	heading := math.Atan2(vz, vx)
+
+	return ShotResult{
+		CarryDistance:  carry,
+		TotalDistance:  carry + roll*math.Cos(heading),
+		Apex:           apex * metersToYard,
+		CarryDeviation: carryDeviation,
+		TotalDeviation: carryDeviation + roll*math.Sin(heading),
+		DescentAngle:   descent,
+		FlightTime:     t,
+	}
+}
+
+// roll computes the outcome of a putt, which rolls on the green from the start.
+func roll(ball StandardizedBallData) ShotResult {
+	speed := ball.Speed * mphToMPS
+	distance := speed * speed / (2 * greenResistance * gravity) * metersToYard
+	hla := ball.HLA * math.Pi / 180
+	return ShotResult{
+		TotalDistance:  distance * math.Cos(hla),
+		TotalDeviation: distance * math.Sin(hla),
+	}
+}
+
+// rollOut returns how far, in meters, a ball landing with the given horizontal and downward speeds
+// and backspin travels before it stops. Each bounce keeps part of the speed, backspin checks the ball,
+// and once it stops bouncing it rolls against the ground's rolling resistance.
+func (g Ground) rollOut(horizontal float64, down float64, omega float64) float64 {
+	if g.RollingResistance <= 0 {
+		return 0
+	}
+	var distance float64
+	for bounce := 0; bounce < maxBounces; bounce++ {
+		horizontal = math.Max(0, g.Friction*horizontal-spinBrake*omega*ballRadius)
+		down *= g.Restitution
+		omega *= 0.5
+		if down < minBounceSpeed {
+			break
+		}
+		distance += horizontal * 2 * down / gravity
+	}
+	return distance + horizontal*horizontal/(2*g.RollingResistance*gravity)
+}
@@ -15,9 +15,9 @@ func NewLogger(config Config) (*zap.Logger, *os.File, error) {
 		return nil, nil, fmt.Errorf("failed to open log file: %w", err)
 	}
 
-	// Only write to the file if the launch monitor is "VIRTUAL"
+	// Only write to the file if the virtual launch monitor is prompting on the terminal
 	var writer io.Writer
-	if config.LaunchMonitor.Name == "VIRTUAL" {
+	if config.LaunchMonitor.Uses("VIRTUAL") && config.Virtual.Prompt {
 		writer = io.MultiWriter(file)
 	} else {
 		writer = io.MultiWriter(os.Stdout, file)
@@ -12,19 +12,14 @@ type StandardizedBallData struct {
 	CarryDistance float64 `json:"CarryDistance,omitempty"`
 }
 
-// ApplyAdjustment applies multipliers to the ball data.
+// ApplyAdjustment applies a modifier to each field of the ball data.
 // It returns a new StandardizedBallData with the adjusted values.
-func (original StandardizedBallData) ApplyAdjustment(multipliers StandardizedBallData) StandardizedBallData {
-	return StandardizedBallData{
-		Speed:         original.Speed * multipliers.Speed,
-		SpinAxis:      original.SpinAxis * multipliers.SpinAxis,
-		TotalSpin:     original.TotalSpin * multipliers.TotalSpin,
-		BackSpin:      original.BackSpin * multipliers.BackSpin,
-		SideSpin:      original.SideSpin * multipliers.SideSpin,
-		HLA:           original.HLA * multipliers.HLA,
-		VLA:           original.VLA * multipliers.VLA,
-		CarryDistance: original.CarryDistance * multipliers.CarryDistance,
+func (original StandardizedBallData) ApplyAdjustment(modifiers BallModifiers) StandardizedBallData {
+	adjusted := original
+	for _, f := range BallFields {
+		*f.Value(&adjusted) = f.Modifier(&modifiers).Apply(*f.Value(&original))
 	}
+	return adjusted
 }
 
 // StandardizedClubData provides a standardized structure for club data.
@@ -41,64 +36,54 @@ type StandardizedClubData struct {
 	ClosureRate          float64 `json:"ClosureRate"`
 }
 
-// ApplyAdjustment applies multipliers to the club data.
+// ApplyAdjustment applies a modifier to each field of the club data.
 // It returns a new StandardizedClubData with the adjusted values.
-func (original StandardizedClubData) ApplyAdjustment(multipliers StandardizedClubData) StandardizedClubData {
-	return StandardizedClubData{
-		Speed:                original.Speed * multipliers.Speed,
-		AngleOfAttack:        original.AngleOfAttack * multipliers.AngleOfAttack,
-		FaceToTarget:         original.FaceToTarget * multipliers.FaceToTarget,
-		Lie:                  original.Lie * multipliers.Lie,
-		Loft:                 original.Loft * multipliers.Loft,
-		Path:                 original.Path * multipliers.Path,
-		SpeedAtImpact:        original.SpeedAtImpact * multipliers.SpeedAtImpact,
-		VerticalFaceImpact:   original.VerticalFaceImpact * multipliers.VerticalFaceImpact,
-		HorizontalFaceImpact: original.HorizontalFaceImpact * multipliers.HorizontalFaceImpact,
-		ClosureRate:          original.ClosureRate * multipliers.ClosureRate,
+func (original StandardizedClubData) ApplyAdjustment(modifiers ClubModifiers) StandardizedClubData {
+	adjusted := original
+	for _, f := range ClubFields {
+		*f.Value(&adjusted) = f.Modifier(&modifiers).Apply(*f.Value(&original))
 	}
+	return adjusted
 }
 
-type ModifierData struct {
-	BallData StandardizedBallData `json:"ball_data"`
-	ClubData StandardizedClubData `json:"club_data"`
+// BallField gives generic access to one StandardizedBallData field by name.
+type BallField struct {
+	Name     string
+	Value    func(*StandardizedBallData) *float64
+	Modifier func(*BallModifiers) *FieldModifier
 }
 
-var DefaultModifiers = ModifierData{
-	BallData: StandardizedBallData{
-		Speed:         1.0,
-		SpinAxis:      1.0,
-		TotalSpin:     1.0,
-		BackSpin:      1.0,
-		SideSpin:      1.0,
-		HLA:           1.0,
-		VLA:           1.0,
-		CarryDistance: 1.0,
-	},
-	ClubData: StandardizedClubData{
-		Speed:                1.0,
-		AngleOfAttack:        1.0,
-		FaceToTarget:         1.0,
-		Lie:                  1.0,
-		Loft:                 1.0,
-		Path:                 1.0,
-		SpeedAtImpact:        1.0,
-		VerticalFaceImpact:   1.0,
-		HorizontalFaceImpact: 1.0,
-		ClosureRate:          1.0,
-	},
+// BallFields lists every StandardizedBallData field in declaration order.
+var BallFields = []BallField{
+	{"Speed", func(b *StandardizedBallData) *float64 { return &b.Speed }, func(m *BallModifiers) *FieldModifier { return &m.Speed }},
+	{"SpinAxis", func(b *StandardizedBallData) *float64 { return &b.SpinAxis }, func(m *BallModifiers) *FieldModifier { return &m.SpinAxis }},
+	{"TotalSpin", func(b *StandardizedBallData) *float64 { return &b.TotalSpin }, func(m *BallModifiers) *FieldModifier { return &m.TotalSpin }},
+	{"BackSpin", func(b *StandardizedBallData) *float64 { return &b.BackSpin }, func(m *BallModifiers) *FieldModifier { return &m.BackSpin }},
+	{"SideSpin", func(b *StandardizedBallData) *float64 { return &b.SideSpin }, func(m *BallModifiers) *FieldModifier { return &m.SideSpin }},
+	{"HLA", func(b *StandardizedBallData) *float64 { return &b.HLA }, func(m *BallModifiers) *FieldModifier { return &m.HLA }},
+	{"VLA", func(b *StandardizedBallData) *float64 { return &b.VLA }, func(m *BallModifiers) *FieldModifier { return &m.VLA }},
+	{"CarryDistance", func(b *StandardizedBallData) *float64 { return &b.CarryDistance }, func(m *BallModifiers) *FieldModifier { return &m.CarryDistance }},
 }
 
-var InteractiveModifiers = ModifierData{
-	BallData: DefaultModifiers.BallData,
-	ClubData: DefaultModifiers.ClubData,
+// ClubField gives generic access to one StandardizedClubData field by name.
+type ClubField struct {
+	Name     string
+	Value    func(*StandardizedClubData) *float64
+	Modifier func(*ClubModifiers) *FieldModifier
 }
 
-func GetModifiers() ModifierData {
-	return InteractiveModifiers
-}
-
-func SetModifiers(md ModifierData) {
-	InteractiveModifiers = md
+// ClubFields lists every StandardizedClubData field in declaration order.
+var ClubFields = []ClubField{
+	{"Speed", func(c *StandardizedClubData) *float64 { return &c.Speed }, func(m *ClubModifiers) *FieldModifier { return &m.Speed }},
+	{"AngleOfAttack", func(c *StandardizedClubData) *float64 { return &c.AngleOfAttack }, func(m *ClubModifiers) *FieldModifier { return &m.AngleOfAttack }},
+	{"FaceToTarget", func(c *StandardizedClubData) *float64 { return &c.FaceToTarget }, func(m *ClubModifiers) *FieldModifier { return &m.FaceToTarget }},
+	{"Lie", func(c *StandardizedClubData) *float64 { return &c.Lie }, func(m *ClubModifiers) *FieldModifier { return &m.Lie }},
+	{"Loft", func(c *StandardizedClubData) *float64 { return &c.Loft }, func(m *ClubModifiers) *FieldModifier { return &m.Loft }},
+	{"Path", func(c *StandardizedClubData) *float64 { return &c.Path }, func(m *ClubModifiers) *FieldModifier { return &m.Path }},
+	{"SpeedAtImpact", func(c *StandardizedClubData) *float64 { return &c.SpeedAtImpact }, func(m *ClubModifiers) *FieldModifier { return &m.SpeedAtImpact }},
+	{"VerticalFaceImpact", func(c *StandardizedClubData) *float64 { return &c.VerticalFaceImpact }, func(m *ClubModifiers) *FieldModifier { return &m.VerticalFaceImpact }},
+	{"HorizontalFaceImpact", func(c *StandardizedClubData) *float64 { return &c.HorizontalFaceImpact }, func(m *ClubModifiers) *FieldModifier { return &m.HorizontalFaceImpact }},
+	{"ClosureRate", func(c *StandardizedClubData) *float64 { return &c.ClosureRate }, func(m *ClubModifiers) *FieldModifier { return &m.ClosureRate }},
 }
 
 // ShotDataOptions provides options for shot data.
@@ -109,7 +94,41 @@ type ShotDataOptions struct {
 	LaunchMonitorBallDetected bool   `json:"LaunchMonitorBallDetected,omitempty"`
 	IsHeartBeat               bool   `json:"IsHeartBeat,omitempty"`
 	ClubType                  string `json:"ClubType,omitempty"`
+
+	// Adjusted marks data that already went through the modifiers and rules, such as a replayed adjusted shot.
+	Adjusted bool `json:"-"`
+
+	// Sources holds each device's shot when several launch monitors were fused into this one.
+	Sources []DeviceShot `json:"-"`
+}
+
+// DeviceShot is the raw shot one launch monitor reported, in the standard convention.
+type DeviceShot struct {
+	Device   string
+	ClubType string
+	Ball     StandardizedBallData
+	Club     StandardizedClubData
 }
 
 // ShotHandlerFunc defines a callback function type that handles shot data.
-type ShotHandlerFunc func(standardBall StandardizedBallData, standardClub StandardizedClubData, shotDataOptions ShotDataOptions)
+// It returns the simulator's result for the shot, or nil when the simulator did not report one.
+type ShotHandlerFunc func(standardBall StandardizedBallData, standardClub StandardizedClubData, shotDataOptions ShotDataOptions) *ShotResult
+
+// PlayerInfo provides a standardized structure for the player state reported by a simulator.
+// Club is empty when the simulator's club has no matching ClubType.
+type PlayerInfo struct {
+	Handed           Handedness `json:"Handed"`
+	Club             ClubType   `json:"Club"`
+	DistanceToTarget float64    `json:"DistanceToTarget"`
+	Surface          string     `json:"Surface"`
+}
+
+// SimulatorResponse provides a standardized structure for responses received from a simulator.
+type SimulatorResponse struct {
+	Code    int         `json:"Code"`
+	Message string      `json:"Message"`
+	Player  *PlayerInfo `json:"Player,omitempty"`
+}
+
+// ResponseHandlerFunc defines a callback function type that handles simulator responses.
+type ResponseHandlerFunc func(response SimulatorResponse)
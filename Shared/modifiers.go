@@ -0,0 +1,243 @@
+package Shared
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sync"
+)
+
+// FieldModifier adjusts one shot field: the value is multiplied, then offset, then clamped to Min and Max when they are set.
+// Offsets and clamps are in the field's standard unit, e.g. mph for speeds and degrees for angles.
+type FieldModifier struct {
+	Multiplier float64  `json:"Multiplier"`
+	Offset     float64  `json:"Offset"`
+	Min        *float64 `json:"Min,omitempty"`
+	Max        *float64 `json:"Max,omitempty"`
+}
+
+// NoModification leaves a field unchanged.
+var NoModification = FieldModifier{Multiplier: 1}
+
+// Apply returns the modified value.
+func (m FieldModifier) Apply(value float64) float64 {
+	value = value*m.Multiplier + m.Offset
+	if m.Min != nil && value < *m.Min {
+		value = *m.Min
+	}
+	if m.Max != nil && value > *m.Max {
+		value = *m.Max
+	}
+	return value
+}
+
+// Validate checks that the modifier's values are finite and its clamps are in order.
+func (m FieldModifier) Validate() error {
+	for _, v := range []float64{m.Multiplier, m.Offset} {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return fmt.Errorf("%v is not a number", v)
+		}
+	}
+	if m.Min != nil && m.Max != nil && *m.Min > *m.Max {
+		return fmt.Errorf("min %g is above max %g", *m.Min, *m.Max)
+	}
+	return nil
+}
+
+// UnmarshalJSON accepts either a bare number, which is a multiplier as in earlier versions,
+// or an object with any of Multiplier, Offset, Min and Max. A bare number replaces the whole modifier,
+// dropping any offset and clamps. An object only changes the keys it lists, so decoding over a modifier
+// keeps the rest, and a null Min or Max removes that clamp. A new modifier starts from NoModification,
+// so a missing Multiplier is 1.
+func (m *FieldModifier) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] != '{' {
+		var multiplier float64
+		if err := json.Unmarshal(data, &multiplier); err != nil {
+			return fmt.Errorf("modifier must be a number or an object: %w", err)
+		}
+		*m = FieldModifier{Multiplier: multiplier}
+		return nil
+	}
+
+	type plain FieldModifier
+	modifier := plain(*m)
+	if *m == (FieldModifier{}) {
+		modifier = plain(NoModification)
+	}
+	if err := json.Unmarshal(data, &modifier); err != nil {
+		return err
+	}
+	*m = FieldModifier(modifier)
+	return nil
+}
+
+// scaled returns the modifier for a field whose unit is factor times the standard unit.
+func (m FieldModifier) scaled(factor float64) FieldModifier {
+	scaled := m
+	scaled.Offset *= factor
+	if m.Min != nil {
+		v := *m.Min * factor
+		scaled.Min = &v
+	}
+	if m.Max != nil {
+		v := *m.Max * factor
+		scaled.Max = &v
+	}
+	return scaled
+}
+
+// BallModifiers holds a modifier for each StandardizedBallData field.
+type BallModifiers struct {
+	Speed         FieldModifier `json:"Speed"`
+	SpinAxis      FieldModifier `json:"SpinAxis"`
+	TotalSpin     FieldModifier `json:"TotalSpin"`
+	BackSpin      FieldModifier `json:"BackSpin"`
+	SideSpin      FieldModifier `json:"SideSpin"`
+	HLA           FieldModifier `json:"HLA"`
+	VLA           FieldModifier `json:"VLA"`
+	CarryDistance FieldModifier `json:"CarryDistance"`
+}
+
+// ClubModifiers holds a modifier for each StandardizedClubData field.
+type ClubModifiers struct {
+	Speed                FieldModifier `json:"Speed"`
+	AngleOfAttack        FieldModifier `json:"AngleOfAttack"`
+	FaceToTarget         FieldModifier `json:"FaceToTarget"`
+	Lie                  FieldModifier `json:"Lie"`
+	Loft                 FieldModifier `json:"Loft"`
+	Path                 FieldModifier `json:"Path"`
+	SpeedAtImpact        FieldModifier `json:"SpeedAtImpact"`
+	VerticalFaceImpact   FieldModifier `json:"VerticalFaceImpact"`
+	HorizontalFaceImpact FieldModifier `json:"HorizontalFaceImpact"`
+	ClosureRate          FieldModifier `json:"ClosureRate"`
+}
+
+type ModifierData struct {
+	BallData BallModifiers `json:"ball_data"`
+	ClubData ClubModifiers `json:"club_data"`
+}
+
+// Validate checks every field modifier.
+func (md ModifierData) Validate() error {
+	for _, f := range BallFields {
+		if err := f.Modifier(&md.BallData).Validate(); err != nil {
+			return fmt.Errorf("ball %s: %w", f.Name, err)
+		}
+	}
+	for _, f := range ClubFields {
+		if err := f.Modifier(&md.ClubData).Validate(); err != nil {
+			return fmt.Errorf("club %s: %w", f.Name, err)
+		}
+	}
+	return nil
+}
+
+// ConvertModifierUnits converts the offsets and clamps of speed and distance modifiers from one unit system to another.
+func ConvertModifierUnits(md ModifierData, from UnitSystem, to UnitSystem) ModifierData {
+	speed := ConvertSpeed(1, from, to)
+	distance := ConvertDistance(1, from, to)
+	converted := md
+	converted.BallData.Speed = md.BallData.Speed.scaled(speed)
+	converted.BallData.CarryDistance = md.BallData.CarryDistance.scaled(distance)
+	converted.ClubData.Speed = md.ClubData.Speed.scaled(speed)
+	converted.ClubData.SpeedAtImpact = md.ClubData.SpeedAtImpact.scaled(speed)
+	return converted
+}
+
+var DefaultModifiers = ModifierData{
+	BallData: BallModifiers{
+		Speed:         NoModification,
+		SpinAxis:      NoModification,
+		TotalSpin:     NoModification,
+		BackSpin:      NoModification,
+		SideSpin:      NoModification,
+		HLA:           NoModification,
+		VLA:           NoModification,
+		CarryDistance: NoModification,
+	},
+	ClubData: ClubModifiers{
+		Speed:                NoModification,
+		AngleOfAttack:        NoModification,
+		FaceToTarget:         NoModification,
+		Lie:                  NoModification,
+		Loft:                 NoModification,
+		Path:                 NoModification,
+		SpeedAtImpact:        NoModification,
+		VerticalFaceImpact:   NoModification,
+		HorizontalFaceImpact: NoModification,
+		ClosureRate:          NoModification,
+	},
+}
+
+var InteractiveModifiers = ModifierData{
+	BallData: DefaultModifiers.BallData,
+	ClubData: DefaultModifiers.ClubData,
+}
+
+// clubModifiers holds the modifier profiles of clubs whose shots are adjusted differently from InteractiveModifiers.
+var clubModifiers = map[ClubType]ModifierData{}
+
+var modifiersMutex sync.RWMutex
+
+// GetModifiers returns the modifiers applied to clubs without a profile of their own.
+func GetModifiers() ModifierData {
+	modifiersMutex.RLock()
+	defer modifiersMutex.RUnlock()
+	return InteractiveModifiers
+}
+
+// SetModifiers sets the modifiers applied to clubs without a profile of their own.
+func SetModifiers(md ModifierData) {
+	modifiersMutex.Lock()
+	defer modifiersMutex.Unlock()
+	InteractiveModifiers = md
+}
+
+// GetClubModifiers returns the modifier profile of a club, if it has one.
+func GetClubModifiers(club ClubType) (ModifierData, bool) {
+	modifiersMutex.RLock()
+	defer modifiersMutex.RUnlock()
+	md, ok := clubModifiers[club]
+	return md, ok
+}
+
+// GetAllClubModifiers returns a copy of every club's modifier profile.
+func GetAllClubModifiers() map[ClubType]ModifierData {
+	modifiersMutex.RLock()
+	defer modifiersMutex.RUnlock()
+	profiles := make(map[ClubType]ModifierData, len(clubModifiers))
+	for club, md := range clubModifiers {
+		profiles[club] = md
+	}
+	return profiles
+}
+
+// SetClubModifiers gives a club its own modifier profile.
+func SetClubModifiers(club ClubType, md ModifierData) {
+	modifiersMutex.Lock()
+	defer modifiersMutex.Unlock()
+	clubModifiers[club] = md
+}
+
+// DeleteClubModifiers removes a club's modifier profile so its shots use the default modifiers again.
+// It reports whether the club had a profile.
+func DeleteClubModifiers(club ClubType) bool {
+	modifiersMutex.Lock()
+	defer modifiersMutex.Unlock()
+	_, ok := clubModifiers[club]
+	delete(clubModifiers, club)
+	return ok
+}
+
+// ModifiersFor returns the modifiers for a shot taken with the given club: the club's profile when it has one,
+// otherwise the default modifiers. It reports whether the club's profile was used.
+func ModifiersFor(club ClubType) (ModifierData, bool) {
+	modifiersMutex.RLock()
+	defer modifiersMutex.RUnlock()
+	if md, ok := clubModifiers[club]; ok {
+		return md, true
+	}
+	return InteractiveModifiers, false
+}
@@ -0,0 +1,306 @@
+package Shared
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Plugin message types exchanged with a plugin process, one JSON object per line.
+const (
+	// PluginConnect is sent to the plugin each time it starts.
+	PluginConnect = "Connect"
+	// PluginClose is sent to the plugin before it is stopped.
+	PluginClose = "Close"
+	// PluginShot is a shot: sent to simulator plugins and sent by launch monitor plugins.
+	PluginShot = "Shot"
+	// PluginResult answers a shot, matched by ID.
+	PluginResult = "Result"
+	// PluginResponse relays a simulator response to a launch monitor plugin.
+	PluginResponse = "Response"
+	// PluginClub tells a launch monitor plugin the club selected in the simulator.
+	PluginClub = "Club"
+	// PluginLog is a log line from the plugin.
+	PluginLog = "Log"
+)
+
+const (
+	// minPluginRestartDelay is the first delay before restarting a plugin that exited; it doubles up to maxPluginRestartDelay.
+	minPluginRestartDelay = time.Second
+	maxPluginRestartDelay = 30 * time.Second
+	// pluginStableAfter is how long a plugin must run before its restart delay is reset.
+	pluginStableAfter = 30 * time.Second
+	// pluginCloseTimeout is how long a plugin has to exit after the Close message before it is killed.
+	pluginCloseTimeout = 5 * time.Second
+	// maxPluginLine is the longest line read from a plugin.
+	maxPluginLine = 1024 * 1024
+)
+
+// ErrPluginNotRunning is returned when a message is sent while the plugin process is restarting.
+var ErrPluginNotRunning = errors.New("plugin is not running")
+
+// PluginMessage is a message exchanged with a plugin process. Only the fields used by its Type are set.
+type PluginMessage struct {
+	Type            string                `json:"Type"`
+	ID              int                   `json:"ID,omitempty"`
+	Role            string                `json:"Role,omitempty"`
+	Version         string                `json:"Version,omitempty"`
+	Handed          Handedness            `json:"Handed,omitempty"`
+	Units           UnitSystem            `json:"Units,omitempty"`
+	BallData        *StandardizedBallData `json:"BallData,omitempty"`
+	ClubData        *StandardizedClubData `json:"ClubData,omitempty"`
+	ShotDataOptions *ShotDataOptions      `json:"ShotDataOptions,omitempty"`
+	Result          *ShotResult           `json:"Result,omitempty"`
+	Code            int                   `json:"Code,omitempty"`
+	Message         string                `json:"Message,omitempty"`
+	Level           string                `json:"Level,omitempty"`
+	ClubType        ClubType              `json:"ClubType,omitempty"`
+}
+
+// PluginProcess runs a plugin executable and restarts it with backoff whenever it exits.
+// Messages are written to its stdin and read from its stdout as newline-delimited JSON;
+// its stderr is logged.
+type PluginProcess struct {
+	name       string
+	command    string
+	args       []string
+	role       string
+	units      UnitSystem
+	log        *zap.SugaredLogger
+	onMessage  func(PluginMessage)
+	onState    func(ConnectionState)
+	stdin      io.WriteCloser
+	cmd        *exec.Cmd
+	mutex      sync.Mutex
+	shutdown   chan struct{}
+	stopped    chan struct{}
+	started    bool
+	stopOnce   sync.Once
+	writeMutex sync.Mutex
+}
+
+// NewPluginProcess creates a supervisor for a plugin command line such as "python3 my_plugin.py --bay 1".
+// Arguments are split on spaces, and units is the unit system the plugin is told shots are exchanged in.
+// onMessage receives every message from the plugin except logs,
+// and onState is told when the plugin starts and exits.
+func NewPluginProcess(commandLine string, role string, units UnitSystem, log *zap.SugaredLogger, onMessage func(PluginMessage), onState func(ConnectionState)) *PluginProcess {
+	fields := strings.Fields(commandLine)
+	p := &PluginProcess{
+		name:      strings.Join(fields, " "),
+		role:      role,
+		units:     units,
+		log:       log,
+		onMessage: onMessage,
+		onState:   onState,
+		shutdown:  make(chan struct{}),
+		stopped:   make(chan struct{}),
+	}
+	if len(fields) > 0 {
+		p.command, p.args = fields[0], fields[1:]
+	}
+	return p
+}
+
+// Start checks the executable exists and starts supervising it in the background.
+func (p *PluginProcess) Start() error {
+	if p.command == "" {
+		return fmt.Errorf("a plugin command is required")
+	}
+	if _, err := exec.LookPath(p.command); err != nil {
+		return fmt.Errorf("plugin %s: %w", p.command, err)
+	}
+	p.started = true
+	go p.supervise()
+	return nil
+}
+
+// supervise starts the plugin and restarts it each time it exits, until Stop is called.
+func (p *PluginProcess) supervise() {
+	defer close(p.stopped)
+	delay := minPluginRestartDelay
+	for {
+		started := time.Now()
+		if err := p.run(); err != nil {
+			p.log.Errorf("plugin %s: %v", p.name, err)
+		}
+		select {
+		case <-p.shutdown:
+			return
+		default:
+		}
+
+		if time.Since(started) > pluginStableAfter {
+			delay = minPluginRestartDelay
+		}
+		p.log.Warnf("plugin %s exited, restarting in %v", p.name, delay)
+		select {
+		case <-time.After(delay):
+		case <-p.shutdown:
+			return
+		}
+		delay *= 2
+		if delay > maxPluginRestartDelay {
+			delay = maxPluginRestartDelay
+		}
+	}
+}
+
+// run starts the plugin once, sends it the Connect message and reads its output until it exits.
+func (p *PluginProcess) run() error {
+	cmd := exec.Command(p.command, p.args...)
+	stdin, err := cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+
+	p.mutex.Lock()
+	p.cmd, p.stdin = cmd, stdin
+	p.mutex.Unlock()
+	p.log.Infof("✅ plugin %s started (pid %d)", p.name, cmd.Process.Pid)
+
+	stderrDone := make(chan struct{})
+	go func() {
+		p.logStderr(stderr)
+		close(stderrDone)
+	}()
+	if err := p.Send(PluginMessage{Type: PluginConnect, Role: p.role, Version: Version, Handed: GetHandedness(), Units: p.units}); err != nil {
+		p.log.Errorf("sending Connect to plugin: %v", err)
+	}
+	if p.onState != nil {
+		p.onState(Connected)
+	}
+
+	// Both pipes are read to the end before Wait closes them. A plugin whose output can no longer be read
+	// is killed, as it would otherwise block writing to a full pipe and never exit to be restarted.
+	if err := p.readMessages(stdout); err != nil {
+		p.log.Errorf("reading plugin output: %v, killing plugin %s", err, p.name)
+		_ = cmd.Process.Kill()
+	}
+	<-stderrDone
+	err = cmd.Wait()
+
+	p.mutex.Lock()
+	p.cmd, p.stdin = nil, nil
+	p.mutex.Unlock()
+	if p.onState != nil {
+		p.onState(Disconnected)
+	}
+	return err
+}
+
+// readMessages reads newline-delimited JSON messages from the plugin's stdout until it closes or cannot be read.
+func (p *PluginProcess) readMessages(stdout io.Reader) error {
+	scanner := bufio.NewScanner(stdout)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxPluginLine)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		var msg PluginMessage
+		if err := json.Unmarshal([]byte(line), &msg); err != nil {
+			p.log.Warnf("ignoring plugin output that is not a JSON message: %s", line)
+			continue
+		}
+		if msg.Type == PluginLog {
+			p.logMessage(msg)
+			continue
+		}
+		if p.onMessage != nil {
+			p.onMessage(msg)
+		}
+	}
+	return scanner.Err()
+}
+
+// logMessage logs a Log message from the plugin at its level.
+func (p *PluginProcess) logMessage(msg PluginMessage) {
+	switch strings.ToLower(msg.Level) {
+	case "error":
+		p.log.Errorf("plugin: %s", msg.Message)
+	case "warn", "warning":
+		p.log.Warnf("plugin: %s", msg.Message)
+	case "debug":
+		p.log.Debugf("plugin: %s", msg.Message)
+	default:
+		p.log.Infof("plugin: %s", msg.Message)
+	}
+}
+
+// logStderr logs each line the plugin writes to stderr.
+func (p *PluginProcess) logStderr(stderr io.Reader) {
+	scanner := bufio.NewScanner(stderr)
+	for scanner.Scan() {
+		p.log.Infof("plugin stderr: %s", scanner.Text())
+	}
+}
+
+// Send writes a message to the plugin.
+func (p *PluginProcess) Send(msg PluginMessage) error {
+	p.mutex.Lock()
+	stdin := p.stdin
+	p.mutex.Unlock()
+	if stdin == nil {
+		return ErrPluginNotRunning
+	}
+
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	p.writeMutex.Lock()
+	defer p.writeMutex.Unlock()
+	if _, err := stdin.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("writing to plugin: %w", err)
+	}
+	return nil
+}
+
+// Stop sends the Close message, waits briefly for the plugin to exit and kills it if it does not.
+func (p *PluginProcess) Stop() {
+	p.stopOnce.Do(func() {
+		close(p.shutdown)
+		if !p.started {
+			return
+		}
+		if err := p.Send(PluginMessage{Type: PluginClose}); err == nil {
+			p.mutex.Lock()
+			stdin := p.stdin
+			p.mutex.Unlock()
+			if stdin != nil {
+				_ = stdin.Close()
+			}
+		}
+		select {
+		case <-p.stopped:
+			return
+		case <-time.After(pluginCloseTimeout):
+		}
+		p.mutex.Lock()
+		if p.cmd != nil && p.cmd.Process != nil {
+			p.log.Warnf("plugin %s did not exit, killing it", p.name)
+			_ = p.cmd.Process.Kill()
+		}
+		p.mutex.Unlock()
+		<-p.stopped
+	})
+}
@@ -0,0 +1,100 @@
+package Shared
+
+import (
+	"fmt"
+	"strings"
+)
+
+// UnitSystem describes the units speeds and distances are expressed in.
+// Angles are always in degrees and spin in rpm.
+type UnitSystem string
+
+const (
+	// Imperial expresses speeds in mph and distances in yards.
+	Imperial UnitSystem = "IMPERIAL"
+	// Metric expresses speeds in m/s and distances in metres.
+	Metric UnitSystem = "METRIC"
+)
+
+// StandardUnits is the unit system of StandardizedBallData, StandardizedClubData, ShotResult and PlayerInfo.
+// Adapters that talk to a device or a simulator in other units convert at their boundary.
+const StandardUnits = Imperial
+
+// ParseUnitSystem parses a unit system name such as "imperial" or "metric".
+func ParseUnitSystem(value string) (UnitSystem, error) {
+	switch u := UnitSystem(strings.ToUpper(value)); u {
+	case Imperial, Metric:
+		return u, nil
+	default:
+		return "", fmt.Errorf("unknown unit system %q (imperial, metric)", value)
+	}
+}
+
+// SpeedUnit returns the label of the unit system's speed unit, e.g. "mph".
+func (u UnitSystem) SpeedUnit() string {
+	if u == Metric {
+		return "m/s"
+	}
+	return "mph"
+}
+
+// DistanceUnit returns the label of the unit system's distance unit, e.g. "yd".
+func (u UnitSystem) DistanceUnit() string {
+	if u == Metric {
+		return "m"
+	}
+	return "yd"
+}
+
+// ConvertSpeed converts a speed from one unit system to another.
+func ConvertSpeed(speed float64, from UnitSystem, to UnitSystem) float64 {
+	return speed * speedFactor(to) / speedFactor(from)
+}
+
+// ConvertDistance converts a distance from one unit system to another.
+func ConvertDistance(distance float64, from UnitSystem, to UnitSystem) float64 {
+	return distance * distanceFactor(to) / distanceFactor(from)
+}
+
+// speedFactor returns the factor from mph to the unit system's speed unit.
+func speedFactor(u UnitSystem) float64 {
+	if u == Metric {
+		return mphToMPS
+	}
+	return 1
+}
+
+// distanceFactor returns the factor from yards to the unit system's distance unit.
+func distanceFactor(u UnitSystem) float64 {
+	if u == Metric {
+		return 1 / metersToYard
+	}
+	return 1
+}
+
+// ConvertBallUnits converts the speeds and distances of ball data from one unit system to another.
+func ConvertBallUnits(ball StandardizedBallData, from UnitSystem, to UnitSystem) StandardizedBallData {
+	converted := ball
+	converted.Speed = ConvertSpeed(ball.Speed, from, to)
+	converted.CarryDistance = ConvertDistance(ball.CarryDistance, from, to)
+	return converted
+}
+
+// ConvertClubUnits converts the speeds of club data from one unit system to another.
+func ConvertClubUnits(club StandardizedClubData, from UnitSystem, to UnitSystem) StandardizedClubData {
+	converted := club
+	converted.Speed = ConvertSpeed(club.Speed, from, to)
+	converted.SpeedAtImpact = ConvertSpeed(club.SpeedAtImpact, from, to)
+	return converted
+}
+
+// ConvertShotResultUnits converts the distances of a shot result from one unit system to another.
+func ConvertShotResultUnits(result ShotResult, from UnitSystem, to UnitSystem) ShotResult {
+	converted := result
+	converted.CarryDistance = ConvertDistance(result.CarryDistance, from, to)
+	converted.TotalDistance = ConvertDistance(result.TotalDistance, from, to)
+	converted.Apex = ConvertDistance(result.Apex, from, to)
+	converted.CarryDeviation = ConvertDistance(result.CarryDeviation, from, to)
+	converted.TotalDeviation = ConvertDistance(result.TotalDeviation, from, to)
+	return converted
+}
@@ -0,0 +1,491 @@
+package E6
+
+import (
+	E6 "Fairway_Bridge/Protocols/E6"
+	"Fairway_Bridge/Shared"
+	"Fairway_Bridge/Simulators"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	dialTimeout = 5 * time.Second
+	// armTimeout is how long a shot waits for E6 to arm again, e.g. while the previous shot is still playing.
+	armTimeout = 10 * time.Second
+	// shotCompleteTimeout is how long to wait for E6 to finish playing a shot and report its outcome.
+	shotCompleteTimeout = 20 * time.Second
+)
+
+var (
+	errNotConnected = errors.New("not connected to E6")
+	errDisarmed     = errors.New("E6 is not armed for a shot")
+)
+
+// session is an authenticated connection to E6 and the decoder that read its handshake.
+type session struct {
+	conn    net.Conn
+	decoder *json.Decoder
+}
+
+// ack is an acknowledgement of a command sent to E6.
+type ack struct {
+	subType string
+	details string
+}
+
+// Simulator connects to an E6 Connect or TruGolf simulator as a third-party launch monitor,
+// speaking the same protocol the Garmin R10 speaks to Fairway Bridge.
+type Simulator struct {
+	IPAddress         string
+	Port              int
+	conn              net.Conn
+	connMutex         sync.Mutex
+	state             Shared.ConnectionState
+	stateSince        time.Time
+	armed             bool
+	armedChan         chan struct{}
+	clubType          Shared.ClubType
+	lastResult        *Shared.ShotResult
+	shotMutex         sync.Mutex
+	acks              chan ack
+	shotComplete      chan Shared.ShotResult
+	ackTimeout        time.Duration
+	maxReconnectDelay time.Duration
+	log               *zap.SugaredLogger
+	onResponse        Shared.ResponseHandlerFunc
+	onConnection      Shared.ConnectionHandlerFunc
+	callbackMutex     sync.Mutex
+	shutdownChan      chan struct{}
+}
+
+// NewSimulator creates a new Simulator instance.
+func NewSimulator(ip string, port int, logger *zap.Logger, config Shared.Config) *Simulator {
+	log := logger.With(zap.String("component", "SIMULATOR"), zap.String("type", "E6")).Sugar()
+
+	log.Infof("initializing E6 client")
+	return &Simulator{
+		IPAddress:         ip,
+		Port:              port,
+		state:             Shared.Disconnected,
+		stateSince:        time.Now(),
+		armedChan:         make(chan struct{}),
+		acks:              make(chan ack, 8),
+		shotComplete:      make(chan Shared.ShotResult, 1),
+		ackTimeout:        time.Duration(config.Simulator.AckTimeoutSeconds) * time.Second,
+		maxReconnectDelay: time.Duration(config.Simulator.ReconnectMaxSeconds) * time.Second,
+		log:               log,
+		shutdownChan:      make(chan struct{}),
+	}
+}
+
+// Connect connects to E6 and keeps the connection alive.
+// If E6 is not running yet, the connection is retried in the background.
+func (e *Simulator) Connect() error {
+	address := net.JoinHostPort(e.IPAddress, strconv.Itoa(e.Port))
+	e.log.Infof("connecting to E6 at %s", address)
+	go e.maintainConnection(address)
+	return nil
+}
+
+// maintainConnection dials E6, authenticates, reads its messages and redials with exponential backoff until shutdown.
+func (e *Simulator) maintainConnection(address string) {
+	e.setState(Shared.Connecting)
+	dial := func() (session, error) {
+		return e.dial(address)
+	}
+	Simulators.MaintainConnection(e.log, "E6", e.maxReconnectDelay, e.shutdownChan, dial, func(s session) {
+		e.log.Infof("✅ connected to E6 at %s", address)
+		e.connMutex.Lock()
+		e.conn = s.conn
+		e.connMutex.Unlock()
+		// E6 is ready for a shot once authenticated; it disarms while a shot plays.
+		e.setArmed(true)
+		e.setState(Shared.Connected)
+
+		e.readMessages(s.conn, s.decoder)
+		e.dropConnection(s.conn)
+	})
+}
+
+// dial connects to E6 and performs the handshake.
+// E6 answers the handshake with a challenge, which is returned to complete authentication.
+func (e *Simulator) dial(address string) (session, error) {
+	conn, err := net.DialTimeout("tcp", address, dialTimeout)
+	if err != nil {
+		return session{}, err
+	}
+	_ = conn.SetDeadline(time.Now().Add(dialTimeout))
+	decoder := json.NewDecoder(conn)
+
+	fail := func(err error) (session, error) {
+		_ = conn.Close()
+		return session{}, err
+	}
+	if err := write(conn, command{Type: "Handshake", ProtocolVersion: protocolVersion}); err != nil {
+		return fail(fmt.Errorf("sending handshake: %w", err))
+	}
+	for {
+		var msg Message
+		if err := decoder.Decode(&msg); err != nil {
+			return fail(fmt.Errorf("reading handshake: %w", err))
+		}
+		switch msg.Type {
+		case "Handshake":
+			e.log.Infof("received handshake, answering challenge")
+			if err := write(conn, command{Type: "Challenge", Challenge: msg.Challenge}); err != nil {
+				return fail(fmt.Errorf("answering challenge: %w", err))
+			}
+		case "Authentication":
+			if msg.Success != "true" {
+				return fail(fmt.Errorf("authentication rejected"))
+			}
+			_ = conn.SetDeadline(time.Time{})
+			return session{conn: conn, decoder: decoder}, nil
+		default:
+			e.log.Debugf("ignoring %s message during handshake", msg.Type)
+		}
+	}
+}
+
+// dropConnection closes a lost connection.
+func (e *Simulator) dropConnection(conn net.Conn) {
+	e.connMutex.Lock()
+	if e.conn == conn {
+		e.conn = nil
+	}
+	e.connMutex.Unlock()
+	_ = conn.Close()
+	e.setArmed(false)
+	e.setState(Shared.Disconnected)
+}
+
+// readMessages handles messages from E6 until the connection fails.
+func (e *Simulator) readMessages(conn net.Conn, decoder *json.Decoder) {
+	for {
+		var msg Message
+		if err := decoder.Decode(&msg); err != nil {
+			if errors.Is(err, io.EOF) {
+				e.log.Warnf("connection closed by E6.")
+			} else if !errors.Is(err, net.ErrClosed) {
+				e.log.Errorf("reading message: %v", err)
+			}
+			return
+		}
+
+		switch msg.Type {
+		case "ACK":
+			e.handleAck(msg)
+		case "SimCommand":
+			e.handleSimCommand(conn, msg)
+		case "Disconnect", "Close":
+			e.log.Infof("received %s", msg.Type)
+			return
+		default:
+			e.log.Warnf("no match for message type: %s (%s)", msg.Type, msg.Details)
+		}
+	}
+}
+
+// handleAck passes an acknowledgement to the shot waiting for it.
+func (e *Simulator) handleAck(msg Message) {
+	var details string
+	if err := json.Unmarshal(msg.Details, &details); err != nil {
+		details = string(msg.Details)
+	}
+	e.log.Infof("received ACK for %s: %s", msg.SubType, details)
+	select {
+	case e.acks <- ack{subType: msg.SubType, details: details}:
+	default:
+		e.log.Warnf("dropping ACK for %s, nothing is waiting for it", msg.SubType)
+	}
+}
+
+// handleSimCommand handles a command sent by E6.
+func (e *Simulator) handleSimCommand(conn net.Conn, msg Message) {
+	switch msg.SubType {
+	case "Ping":
+		e.log.Debugf("received Ping, sending Pong")
+		e.connMutex.Lock()
+		err := write(conn, command{Type: "Pong"})
+		e.connMutex.Unlock()
+		if err != nil {
+			e.log.Errorf("sending Pong: %v", err)
+		}
+	case "Arm":
+		e.log.Infof("received Arm")
+		e.setArmed(true)
+	case "Disarm":
+		e.log.Infof("received Disarm")
+		e.setArmed(false)
+	case "SetClubType":
+		e.log.Infof("E6 changed club to %s", msg.ClubType)
+		e.setClubType(msg.ClubType)
+		e.respond(Shared.SimulatorResponse{
+			Code:    201,
+			Message: "Club changed",
+			Player:  &Shared.PlayerInfo{Club: msg.ClubType},
+		})
+	case "ShotComplete":
+		var details ShotCompleteDetails
+		if err := json.Unmarshal(msg.Details, &details); err != nil {
+			e.log.Errorf("parsing ShotComplete: %v", err)
+			return
+		}
+		result := ConvertShotComplete(details)
+		e.log.Infof("⛳️ shot complete: carry %.1f yd, total %.1f yd", result.CarryDistance, result.TotalDistance)
+		select {
+		case e.shotComplete <- result:
+		default:
+			e.log.Warnf("dropping ShotComplete, nothing is waiting for it")
+		}
+		e.respond(Shared.SimulatorResponse{Code: 200, Message: "Shot complete"})
+	default:
+		e.log.Warnf("no match for SimCommand: %s", msg.SubType)
+	}
+}
+
+// LaunchShot sends a shot to E6, waiting for it to be armed and for each command to be acknowledged.
+// When acknowledgements are awaited, it also waits for E6 to report the outcome of the shot.
+func (e *Simulator) LaunchShot(ballData Shared.StandardizedBallData, clubData Shared.StandardizedClubData, shotDataOptions Shared.ShotDataOptions) error {
+	if shotDataOptions.IsHeartBeat {
+		return nil
+	}
+	e.shotMutex.Lock()
+	defer e.shotMutex.Unlock()
+
+	e.setLastResult(nil)
+	if e.ConnectionStatus().State != Shared.Connected {
+		return errNotConnected
+	}
+	if err := e.waitArmed(); err != nil {
+		return err
+	}
+	e.drain()
+
+	ballOut, clubOut := E6.ConvertToE6(ballData, clubData)
+	clubType := Shared.ClubType(shotDataOptions.ClubType)
+	if clubType != "" && clubType != e.getClubType() {
+		if err := e.command(command{Type: "SetClubType", ClubType: clubType}); err != nil {
+			return err
+		}
+		e.setClubType(clubType)
+	}
+	if err := e.command(command{Type: "SetBallData", BallData: &ballOut}); err != nil {
+		return err
+	}
+	if shotDataOptions.ContainsClubData {
+		if err := e.command(command{Type: "SetClubData", ClubData: &clubOut}); err != nil {
+			return err
+		}
+	}
+	if err := e.command(command{Type: "SendShot"}); err != nil {
+		return err
+	}
+	e.log.Infof("shot sent successfully")
+
+	if e.ackTimeout <= 0 {
+		return nil
+	}
+	select {
+	case result := <-e.shotComplete:
+		e.setLastResult(&result)
+	case <-time.After(shotCompleteTimeout):
+		e.log.Warnf("E6 did not report the shot outcome within %s", shotCompleteTimeout)
+	}
+	return nil
+}
+
+// command sends a command and waits for E6 to acknowledge it.
+func (e *Simulator) command(cmd command) error {
+	e.connMutex.Lock()
+	if e.conn == nil {
+		e.connMutex.Unlock()
+		return errNotConnected
+	}
+	e.log.Infof("sending %s", cmd.Type)
+	err := write(e.conn, cmd)
+	e.connMutex.Unlock()
+	if err != nil {
+		return fmt.Errorf("sending %s: %w", cmd.Type, err)
+	}
+
+	if e.ackTimeout <= 0 {
+		return nil
+	}
+	timeout := time.After(e.ackTimeout)
+	for {
+		select {
+		case a := <-e.acks:
+			if a.subType != cmd.Type {
+				e.log.Debugf("ignoring ACK for %s while waiting for %s", a.subType, cmd.Type)
+				continue
+			}
+			if !strings.HasPrefix(a.details, "Success") {
+				return &Simulators.ShotRejectedError{Message: fmt.Sprintf("%s: %s", cmd.Type, a.details)}
+			}
+			return nil
+		case <-timeout:
+			e.log.Warnf("E6 did not acknowledge %s within %s", cmd.Type, e.ackTimeout)
+			return Simulators.ErrShotNotAcknowledged
+		}
+	}
+}
+
+// drain discards acknowledgements and shot outcomes left over from an earlier shot.
+func (e *Simulator) drain() {
+	for {
+		select {
+		case <-e.acks:
+		case <-e.shotComplete:
+		default:
+			return
+		}
+	}
+}
+
+// write sends one JSON message followed by a newline.
+func write(conn net.Conn, cmd command) error {
+	data, err := json.Marshal(cmd)
+	if err != nil {
+		return err
+	}
+	_, err = conn.Write(append(data, '\n'))
+	return err
+}
+
+// setArmed records whether E6 is ready for a shot.
+func (e *Simulator) setArmed(armed bool) {
+	e.connMutex.Lock()
+	defer e.connMutex.Unlock()
+	if armed && !e.armed {
+		close(e.armedChan)
+	} else if !armed && e.armed {
+		e.armedChan = make(chan struct{})
+	}
+	e.armed = armed
+}
+
+// waitArmed waits for E6 to be ready for a shot.
+func (e *Simulator) waitArmed() error {
+	e.connMutex.Lock()
+	armedChan := e.armedChan
+	e.connMutex.Unlock()
+	select {
+	case <-armedChan:
+		return nil
+	case <-time.After(armTimeout):
+		return errDisarmed
+	}
+}
+
+// getClubType returns the club E6 is set to.
+func (e *Simulator) getClubType() Shared.ClubType {
+	e.connMutex.Lock()
+	defer e.connMutex.Unlock()
+	return e.clubType
+}
+
+// setClubType records the club E6 is set to.
+func (e *Simulator) setClubType(clubType Shared.ClubType) {
+	e.connMutex.Lock()
+	defer e.connMutex.Unlock()
+	e.clubType = clubType
+}
+
+// setLastResult records the outcome of the last shot.
+func (e *Simulator) setLastResult(result *Shared.ShotResult) {
+	e.connMutex.Lock()
+	defer e.connMutex.Unlock()
+	e.lastResult = result
+}
+
+// LastShotResult returns the outcome E6 reported for the last shot.
+func (e *Simulator) LastShotResult() (Shared.ShotResult, bool) {
+	e.connMutex.Lock()
+	defer e.connMutex.Unlock()
+	if e.lastResult == nil {
+		return Shared.ShotResult{}, false
+	}
+	return *e.lastResult, true
+}
+
+// setState records a connection state change and reports it.
+func (e *Simulator) setState(state Shared.ConnectionState) {
+	e.connMutex.Lock()
+	if e.state == state {
+		e.connMutex.Unlock()
+		return
+	}
+	e.state = state
+	e.stateSince = time.Now()
+	status := Shared.ConnectionStatus{State: e.state, Since: e.stateSince}
+	e.connMutex.Unlock()
+
+	e.log.Infof("connection state changed to %s", state)
+	e.callbackMutex.Lock()
+	callback := e.onConnection
+	e.callbackMutex.Unlock()
+	if callback != nil {
+		callback(status)
+	}
+}
+
+// ConnectionStatus returns the current connection state. Shots are not queued while E6 is disconnected.
+func (e *Simulator) ConnectionStatus() Shared.ConnectionStatus {
+	e.connMutex.Lock()
+	defer e.connMutex.Unlock()
+	return Shared.ConnectionStatus{State: e.state, Since: e.stateSince}
+}
+
+// SetOnConnectionCallback sets the callback function for connection state changes.
+func (e *Simulator) SetOnConnectionCallback(callback Shared.ConnectionHandlerFunc) {
+	e.callbackMutex.Lock()
+	defer e.callbackMutex.Unlock()
+	e.onConnection = callback
+}
+
+// Convention returns the E6 data convention.
+func (e *Simulator) Convention() Shared.Convention {
+	return E6.Convention
+}
+
+// SetOnResponseCallback sets the callback function for E6 messages reported as simulator responses.
+// The callbacks are set after Connect, so they are guarded from the connection's reader.
+func (e *Simulator) SetOnResponseCallback(callback Shared.ResponseHandlerFunc) {
+	e.callbackMutex.Lock()
+	defer e.callbackMutex.Unlock()
+	e.onResponse = callback
+}
+
+// respond reports an E6 message to the response callback, if one is set.
+func (e *Simulator) respond(response Shared.SimulatorResponse) {
+	e.callbackMutex.Lock()
+	callback := e.onResponse
+	e.callbackMutex.Unlock()
+	if callback != nil {
+		callback(response)
+	}
+}
+
+// Close closes the connection and stops reconnecting.
+func (e *Simulator) Close() error {
+	close(e.shutdownChan)
+	e.connMutex.Lock()
+	defer e.connMutex.Unlock()
+	if e.conn != nil {
+		e.log.Infof("disconnecting from E6")
+		err := e.conn.Close()
+		e.conn = nil
+		return err
+	}
+	return nil
+}
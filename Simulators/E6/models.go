@@ -0,0 +1,54 @@
+package E6
+
+import (
+	E6 "Fairway_Bridge/Protocols/E6"
+	"Fairway_Bridge/Shared"
+	"encoding/json"
+)
+
+const (
+	protocolVersion = "1.0.0.5"
+	feetPerYard     = 3.0
+)
+
+// Message is a message received from the E6 simulator.
+// Details is an acknowledgement text for ACK messages and an object for ShotComplete.
+type Message struct {
+	Type      string          `json:"Type"`
+	SubType   string          `json:"SubType,omitempty"`
+	Challenge string          `json:"Challenge,omitempty"`
+	Success   string          `json:"Success,omitempty"`
+	ClubType  Shared.ClubType `json:"ClubType,omitempty"`
+	Details   json.RawMessage `json:"Details,omitempty"`
+}
+
+// command is a message sent to the E6 simulator.
+type command struct {
+	Type            string          `json:"Type"`
+	ProtocolVersion string          `json:"ProtocolVersion,omitempty"`
+	Challenge       string          `json:"Challenge,omitempty"`
+	ClubType        Shared.ClubType `json:"ClubType,omitempty"`
+	BallData        *E6.BallData    `json:"BallData,omitempty"`
+	ClubData        *E6.ClubData    `json:"ClubData,omitempty"`
+}
+
+// ShotCompleteDetails is the outcome of a shot reported in a ShotComplete command.
+// E6 reports distances and deviations in feet.
+type ShotCompleteDetails struct {
+	Apex               float64 `json:"Apex"`
+	CarryDistance      float64 `json:"CarryDistance"`
+	CarryDeviationFeet float64 `json:"CarryDeviationFeet"`
+	TotalDistance      float64 `json:"TotalDistance"`
+	TotalDeviationFeet float64 `json:"TotalDeviationFeet"`
+}
+
+// ConvertShotComplete converts ShotComplete details to a shot result in yards.
+func ConvertShotComplete(details ShotCompleteDetails) Shared.ShotResult {
+	return Shared.ShotResult{
+		CarryDistance:  details.CarryDistance / feetPerYard,
+		TotalDistance:  details.TotalDistance / feetPerYard,
+		Apex:           details.Apex / feetPerYard,
+		CarryDeviation: details.CarryDeviationFeet / feetPerYard,
+		TotalDeviation: details.TotalDeviationFeet / feetPerYard,
+	}
+}
@@ -0,0 +1,169 @@
+package Fanout
+
+import (
+	"Fairway_Bridge/Shared"
+	"Fairway_Bridge/Simulators"
+	"errors"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// secondaryQueueSize is how many shots a secondary target can fall behind before its shots are dropped.
+const secondaryQueueSize = 32
+
+// Target is one simulator that receives every shot.
+type Target struct {
+	Name       string
+	Controller Simulators.SimulatorController
+	Modifiers  TargetModifiers
+	Primary    bool
+}
+
+// shot is a shot waiting to be sent to a secondary target.
+type shot struct {
+	ball    Shared.StandardizedBallData
+	club    Shared.StandardizedClubData
+	options Shared.ShotDataOptions
+}
+
+// secondary sends shots to a secondary target in order, on its own goroutine.
+type secondary struct {
+	Target
+	shots chan shot
+}
+
+// Simulator sends each shot to several simulators.
+// The primary target's outcome is returned to the Router; secondary targets are sent to in the background,
+// so a slow or failing secondary never holds up the primary.
+type Simulator struct {
+	primary     Target
+	secondaries []*secondary
+	log         *zap.SugaredLogger
+}
+
+// NewSimulator creates a simulator that fans shots out to the given targets, one of which must be primary.
+// The targets are expected to be connected already.
+func NewSimulator(logger *zap.Logger, targets []Target) *Simulator {
+	log := logger.With(zap.String("component", "SIMULATOR"), zap.String("type", "FANOUT")).Sugar()
+	s := &Simulator{log: log}
+	for _, t := range targets {
+		if t.Primary {
+			s.primary = t
+		} else {
+			s.secondaries = append(s.secondaries, &secondary{Target: t, shots: make(chan shot, secondaryQueueSize)})
+		}
+	}
+	return s
+}
+
+// Connect starts sending to the secondary targets.
+func (s *Simulator) Connect() error {
+	names := []string{s.primary.Name + " (primary)"}
+	for _, t := range s.secondaries {
+		t.Controller.SetOnResponseCallback(func(response Shared.SimulatorResponse) {
+			s.log.Infof("response from %s: Code=%d, Message=%s", t.Name, response.Code, response.Message)
+		})
+		go s.run(t)
+		names = append(names, t.Name)
+	}
+	s.log.Infof("✅ sending shots to %s", strings.Join(names, ", "))
+	return nil
+}
+
+// run sends queued shots to a secondary target until it is closed.
+func (s *Simulator) run(t *secondary) {
+	for sh := range t.shots {
+		delivery := Simulators.DeliveryOf(send(t.Target, sh))
+		if delivery.Status == Shared.Delivered {
+			s.log.Infof("✅ shot sent to %s", t.Name)
+		} else {
+			s.log.Warnf("shot to %s: %s %s", t.Name, delivery.Status, delivery.Message)
+		}
+	}
+}
+
+// send applies the target's modifiers and sends a shot in its convention.
+func send(t Target, sh shot) error {
+	ball, club := t.Modifiers.apply(sh.ball, sh.club)
+	handed := Shared.GetHandedness()
+	ball = Shared.ConvertBallData(ball, Shared.StandardConvention, t.Controller.Convention(), handed)
+	club = Shared.ConvertClubData(club, Shared.StandardConvention, t.Controller.Convention(), handed)
+	return t.Controller.LaunchShot(ball, club, sh.options)
+}
+
+// LaunchShot queues the shot for every secondary target and sends it to the primary target,
+// returning the primary target's outcome.
+func (s *Simulator) LaunchShot(ballData Shared.StandardizedBallData, clubData Shared.StandardizedClubData, shotDataOptions Shared.ShotDataOptions) error {
+	sh := shot{ball: ballData, club: clubData, options: shotDataOptions}
+	for _, t := range s.secondaries {
+		select {
+		case t.shots <- sh:
+		default:
+			s.log.Errorf("%s is %d shots behind, dropping the shot for it", t.Name, secondaryQueueSize)
+		}
+	}
+	return send(s.primary, sh)
+}
+
+// SetOnResponseCallback sets the callback function for the primary target's responses.
+// Responses from secondary targets are only logged.
+func (s *Simulator) SetOnResponseCallback(f Shared.ResponseHandlerFunc) {
+	s.primary.Controller.SetOnResponseCallback(f)
+}
+
+// Convention returns the standard convention, as each target's shot is converted when it is sent.
+func (s *Simulator) Convention() Shared.Convention {
+	return Shared.StandardConvention
+}
+
+// LastShotResult returns the primary target's result for the last shot.
+func (s *Simulator) LastShotResult() (Shared.ShotResult, bool) {
+	reporter, ok := s.primary.Controller.(Simulators.ShotResultReporter)
+	if !ok {
+		return Shared.ShotResult{}, false
+	}
+	result, ok := reporter.LastShotResult()
+	if !ok {
+		return result, false
+	}
+	return Shared.ConvertShotResult(result, s.primary.Controller.Convention(), Shared.StandardConvention, Shared.GetHandedness()), true
+}
+
+// ConnectionStatus returns the primary target's connection status.
+func (s *Simulator) ConnectionStatus() Shared.ConnectionStatus {
+	if reporter, ok := s.primary.Controller.(Simulators.ConnectionReporter); ok {
+		return reporter.ConnectionStatus()
+	}
+	return Shared.ConnectionStatus{State: Shared.Connected}
+}
+
+// SetOnConnectionCallback sets the callback function for the primary target's connection state changes.
+func (s *Simulator) SetOnConnectionCallback(f Shared.ConnectionHandlerFunc) {
+	if reporter, ok := s.primary.Controller.(Simulators.ConnectionReporter); ok {
+		reporter.SetOnConnectionCallback(f)
+	}
+}
+
+// Targets returns every target, the primary first.
+func (s *Simulator) Targets() []Target {
+	targets := []Target{s.primary}
+	for _, t := range s.secondaries {
+		targets = append(targets, t.Target)
+	}
+	return targets
+}
+
+// Close stops sending to the secondary targets and closes every target.
+func (s *Simulator) Close() error {
+	var errs []error
+	for _, t := range s.Targets() {
+		if err := t.Controller.Close(); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, t := range s.secondaries {
+		close(t.shots)
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,150 @@
+package Fanout
+
+import (
+	"Fairway_Bridge/Shared"
+	"fmt"
+	"strings"
+)
+
+// TargetConfig describes one simulator that receives every shot.
+type TargetConfig struct {
+	Name      string          `json:"name"`
+	Simulator string          `json:"simulator"`
+	IPAddress string          `json:"ip"`
+	Port      int             `json:"port"`
+	QueueFile string          `json:"queue_file"`
+	URL       string          `json:"url"`
+	Command   string          `json:"command"`
+	Units     string          `json:"units"`
+	Primary   bool            `json:"primary"`
+	Modifiers TargetModifiers `json:"modifiers"`
+}
+
+// TargetModifiers are modifiers applied to a target's copy of the shot, by field name, e.g. Speed: 1.02
+// for a multiplier or HLA: {Offset: -1} for an offset. Fields that are not listed are left unchanged.
+type TargetModifiers struct {
+	Ball map[string]Shared.FieldModifier `json:"ball"`
+	Club map[string]Shared.FieldModifier `json:"club"`
+}
+
+// targetsFile is the layout of a targets file.
+type targetsFile struct {
+	Targets []TargetConfig `json:"targets"`
+}
+
+// LoadTargets reads the targets from a YAML or JSON file and validates them.
+// When no target is marked primary, the first one is.
+func LoadTargets(path string) ([]TargetConfig, error) {
+	var file targetsFile
+	if err := Shared.ReadConfigFile(path, &file); err != nil {
+		return nil, err
+	}
+	if err := validate(file.Targets); err != nil {
+		return nil, fmt.Errorf("targets %s: %w", path, err)
+	}
+	return file.Targets, nil
+}
+
+// validate checks the targets and normalises their names.
+func validate(targets []TargetConfig) error {
+	if len(targets) == 0 {
+		return fmt.Errorf("no targets")
+	}
+	names := map[string]bool{}
+	primaries := 0
+	for i := range targets {
+		t := &targets[i]
+		t.Simulator = strings.ToUpper(t.Simulator)
+		if t.Simulator == "" {
+			return fmt.Errorf("target %d has no simulator", i+1)
+		}
+		if t.Simulator == "FANOUT" {
+			return fmt.Errorf("target %d cannot be another fan-out", i+1)
+		}
+		if t.Name == "" {
+			t.Name = fmt.Sprintf("%s-%d", t.Simulator, i+1)
+		}
+		t.Name = strings.ToUpper(t.Name)
+		if names[t.Name] {
+			return fmt.Errorf("target name %s is used twice", t.Name)
+		}
+		names[t.Name] = true
+		if t.Units != "" {
+			units, err := Shared.ParseUnitSystem(t.Units)
+			if err != nil {
+				return fmt.Errorf("target %s: %w", t.Name, err)
+			}
+			t.Units = string(units)
+		}
+		if t.Primary {
+			primaries++
+		}
+		if err := t.Modifiers.validate(); err != nil {
+			return fmt.Errorf("target %s: %w", t.Name, err)
+		}
+	}
+	switch primaries {
+	case 0:
+		targets[0].Primary = true
+	case 1:
+	default:
+		return fmt.Errorf("only one target can be primary")
+	}
+	return nil
+}
+
+// validate checks that every modified field exists and its modifier is valid.
+func (m TargetModifiers) validate() error {
+	for name, modifier := range m.Ball {
+		if _, ok := ballField(name); !ok {
+			return fmt.Errorf("unknown ball field %s", name)
+		}
+		if err := modifier.Validate(); err != nil {
+			return fmt.Errorf("ball %s: %w", name, err)
+		}
+	}
+	for name, modifier := range m.Club {
+		if _, ok := clubField(name); !ok {
+			return fmt.Errorf("unknown club field %s", name)
+		}
+		if err := modifier.Validate(); err != nil {
+			return fmt.Errorf("club %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// apply modifies the listed fields of a shot.
+func (m TargetModifiers) apply(ball Shared.StandardizedBallData, club Shared.StandardizedClubData) (Shared.StandardizedBallData, Shared.StandardizedClubData) {
+	for name, modifier := range m.Ball {
+		if f, ok := ballField(name); ok {
+			*f.Value(&ball) = modifier.Apply(*f.Value(&ball))
+		}
+	}
+	for name, modifier := range m.Club {
+		if f, ok := clubField(name); ok {
+			*f.Value(&club) = modifier.Apply(*f.Value(&club))
+		}
+	}
+	return ball, club
+}
+
+// ballField finds a ball field by name, ignoring case.
+func ballField(name string) (Shared.BallField, bool) {
+	for _, f := range Shared.BallFields {
+		if strings.EqualFold(f.Name, name) {
+			return f, true
+		}
+	}
+	return Shared.BallField{}, false
+}
+
+// clubField finds a club field by name, ignoring case.
+func clubField(name string) (Shared.ClubField, bool) {
+	for _, f := range Shared.ClubFields {
+		if strings.EqualFold(f.Name, name) {
+			return f, true
+		}
+	}
+	return Shared.ClubField{}, false
+}
@@ -2,175 +2,343 @@ package GSPro
 
 import (
 	"Fairway_Bridge/Shared"
-	"bufio"
-	"bytes"
+	"Fairway_Bridge/Simulators"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"go.uber.org/zap"
 	"io"
 	"net"
+	"sync"
 	"sync/atomic"
 	"time"
 )
 
-const HeartbeatInterval = 5 * time.Second
+const (
+	HeartbeatInterval = 5 * time.Second
+
+	dialTimeout = 5 * time.Second
+)
 
 // Simulator handles connecting and sending shot data to GSPro.
+// It reconnects with exponential backoff when the connection drops and queues shots until it is back.
 type Simulator struct {
-	IPAddress    string
-	Port         int
-	DeviceID     string
-	Units        string
-	ShotNumber   *int32
-	APIVersion   string
-	conn         net.Conn
-	log          *zap.SugaredLogger
-	shutdownChan chan struct{}
+	IPAddress         string
+	Port              int
+	DeviceID          string
+	Units             Shared.UnitSystem
+	ShotNumber        *int32
+	APIVersion        string
+	conn              net.Conn
+	connMutex         sync.Mutex
+	state             Shared.ConnectionState
+	stateSince        time.Time
+	queue             []queuedShot
+	queueMaxAge       time.Duration
+	queueFile         string
+	maxReconnectDelay time.Duration
+	pending           []*pendingShot
+	pendingMutex      sync.Mutex
+	ackTimeout        time.Duration
+	log               *zap.SugaredLogger
+	onResponse        Shared.ResponseHandlerFunc
+	lastPlayer        *Shared.SimulatorResponse
+	responseMutex     sync.Mutex
+	onConnection      Shared.ConnectionHandlerFunc
+	shutdownChan      chan struct{}
 }
 
 // NewSimulator creates a new Simulator instance.
-func NewSimulator(ip string, port int, logger *zap.Logger) *Simulator {
+func NewSimulator(ip string, port int, logger *zap.Logger, config Shared.Config) *Simulator {
 	log := logger.With(zap.String("component", "SIMULATOR"), zap.String("type", "GSPRO")).Sugar()
 
-	log.Infof("initializing GSPro client")
+	log.Infof("initializing GSPro client in %s units", config.Simulator.Units)
 	startingShotNumber := int32(0)
 	return &Simulator{
-		IPAddress:    ip,
-		Port:         port,
-		DeviceID:     "GSPro LM 1.1",
-		Units:        "Yards",
-		ShotNumber:   &startingShotNumber,
-		APIVersion:   "1",
-		log:          log,
-		shutdownChan: make(chan struct{}),
+		IPAddress:         ip,
+		Port:              port,
+		DeviceID:          "GSPro LM 1.1",
+		Units:             config.Simulator.Units,
+		ShotNumber:        &startingShotNumber,
+		APIVersion:        "1",
+		state:             Shared.Disconnected,
+		stateSince:        time.Now(),
+		queueMaxAge:       time.Duration(config.Simulator.QueueMaxAgeSeconds) * time.Second,
+		queueFile:         config.Simulator.QueueFile,
+		maxReconnectDelay: time.Duration(config.Simulator.ReconnectMaxSeconds) * time.Second,
+		ackTimeout:        time.Duration(config.Simulator.AckTimeoutSeconds) * time.Second,
+		log:               log,
+		shutdownChan:      make(chan struct{}),
 	}
 }
 
-// Connect establishes the TCP connection to GSPro.
+// Connect establishes the TCP connection to GSPro and keeps it alive.
+// If GSPro is not running yet, the connection is retried in the background.
 func (g *Simulator) Connect() error {
-	address := fmt.Sprintf("%s:%d", g.IPAddress, g.Port)
-	g.log.Infof("connecting to GSPro at %s", address)
-	conn, err := net.Dial("tcp", address)
-	if err != nil {
-		g.log.Errorf("connecting to GSPro: %v", err)
-		return err
+	if err := g.loadQueue(); err != nil {
+		g.log.Errorf("loading shot queue from %s: %v", g.queueFile, err)
 	}
-	g.conn = conn
-	g.log.Infof("connected to GSPro Connect at %s", address)
 
-	go g.readResponses()
+	address := fmt.Sprintf("%s:%d", g.IPAddress, g.Port)
+	g.log.Infof("connecting to GSPro at %s", address)
+	go g.maintainConnection(address)
 	go g.startHeartbeat()
 
 	return nil
 }
 
-// startHeartbeat sends a heartbeat message every HeartbeatInterval.
-func (g *Simulator) startHeartbeat() {
-	go func() {
-		for {
-			time.Sleep(HeartbeatInterval)
-			_ = g.LaunchShot(Shared.StandardizedBallData{}, Shared.StandardizedClubData{}, Shared.ShotDataOptions{
-				IsHeartBeat:               true,
-				LaunchMonitorIsReady:      true,
-				LaunchMonitorBallDetected: true,
-				ContainsBallData:          false,
-				ContainsClubData:          false,
-			})
-		}
-	}()
+// maintainConnection dials GSPro, reads its responses and redials with exponential backoff until shutdown.
+func (g *Simulator) maintainConnection(address string) {
+	// The state stays CONNECTING until the first connection, and DISCONNECTED while retrying after a drop.
+	g.setState(Shared.Connecting)
+	dial := func() (net.Conn, error) {
+		return net.DialTimeout("tcp", address, dialTimeout)
+	}
+	Simulators.MaintainConnection(g.log, "GSPro", g.maxReconnectDelay, g.shutdownChan, dial, func(conn net.Conn) {
+		g.log.Infof("connected to GSPro Connect at %s", address)
+		g.connMutex.Lock()
+		g.conn = conn
+		g.flushQueue()
+		g.connMutex.Unlock()
+		g.setState(Shared.Connected)
+
+		g.readResponses(conn)
+		g.dropConnection(conn)
+	})
+}
+
+// dropConnection closes a lost connection so the next shots are queued.
+func (g *Simulator) dropConnection(conn net.Conn) {
+	g.connMutex.Lock()
+	if g.conn == conn {
+		g.conn = nil
+	}
+	g.connMutex.Unlock()
+	_ = conn.Close()
+	g.failPending(errConnectionLost)
+	g.setState(Shared.Disconnected)
 }
 
-// readResponses continuously reads responses from GSPro.
-func (g *Simulator) readResponses() {
-	reader := bufio.NewReader(g.conn) // Buffered Reader for efficiency
-	var buffer bytes.Buffer           // Buffer to accumulate fragmented messages
+// setState records a connection state change and reports it.
+func (g *Simulator) setState(state Shared.ConnectionState) {
+	g.connMutex.Lock()
+	if g.state == state {
+		g.connMutex.Unlock()
+		return
+	}
+	g.state = state
+	g.stateSince = time.Now()
+	status := g.status()
+	g.connMutex.Unlock()
 
-	defer func() { // Recover from unexpected errors
-		if r := recover(); r != nil {
-			g.log.Errorf("panic reading response: %v", r)
+	g.log.Infof("connection state changed to %s (%d queued shots)", state, status.QueuedShots)
+	if g.onConnection != nil {
+		g.onConnection(status)
+	}
+}
+
+// status returns the connection status. The caller must hold connMutex.
+func (g *Simulator) status() Shared.ConnectionStatus {
+	return Shared.ConnectionStatus{State: g.state, Since: g.stateSince, QueuedShots: len(g.queue)}
+}
+
+// ConnectionStatus returns the current connection state and the number of queued shots.
+func (g *Simulator) ConnectionStatus() Shared.ConnectionStatus {
+	g.connMutex.Lock()
+	defer g.connMutex.Unlock()
+	return g.status()
+}
+
+// SetOnConnectionCallback sets the callback function for connection state changes.
+func (g *Simulator) SetOnConnectionCallback(callback Shared.ConnectionHandlerFunc) {
+	g.onConnection = callback
+}
+
+// flushQueue sends the queued shots in order, keeping any that could not be sent.
+// The caller must hold connMutex.
+func (g *Simulator) flushQueue() {
+	g.pruneQueue()
+	if len(g.queue) == 0 {
+		g.saveQueue()
+		return
+	}
+	g.log.Infof("sending %d queued shots", len(g.queue))
+	for len(g.queue) > 0 {
+		// Nobody waits for the answer to a queued shot, but tracking it keeps later answers matched to their shots.
+		p := g.track(g.queue[0].Shot.ShotNumber)
+		if err := g.write(g.queue[0].Shot); err != nil {
+			g.log.Errorf("sending queued shot %d: %v", g.queue[0].Shot.ShotNumber, err)
+			g.untrack(p)
+			break
 		}
-	}()
+		g.log.Infof("✅ queued shot %d sent", g.queue[0].Shot.ShotNumber)
+		g.queue = g.queue[1:]
+	}
+	g.saveQueue()
+}
 
+// startHeartbeat sends a heartbeat message every HeartbeatInterval while connected.
+func (g *Simulator) startHeartbeat() {
 	for {
 		select {
 		case <-g.shutdownChan:
-			g.log.Info("shutdown signal received; exiting run loop.")
 			return
-		default:
+		case <-time.After(HeartbeatInterval):
 		}
-
-		// Read into a temporary buffer
-		temp := make([]byte, 1024) // Read chunks of data
-		n, err := reader.Read(temp)
-		if err != nil {
-			if err == io.EOF { // Handle disconnection gracefully
-				g.log.Warnf("connection closed by GSPro.")
-				return
-			}
-			g.log.Errorf("reading response: %v", err)
+		if g.ConnectionStatus().State != Shared.Connected {
 			continue
 		}
+		_ = g.LaunchShot(Shared.StandardizedBallData{}, Shared.StandardizedClubData{}, Shared.ShotDataOptions{
+			IsHeartBeat:               true,
+			LaunchMonitorIsReady:      true,
+			LaunchMonitorBallDetected: true,
+			ContainsBallData:          false,
+			ContainsClubData:          false,
+		})
+	}
+}
 
-		buffer.Write(temp[:n])
-
-		// Try to decode JSON messages
-		for {
-			var resp GSProResponse
-			decoder := json.NewDecoder(&buffer)
-			if err := decoder.Decode(&resp); err != nil {
-				if err == io.EOF {
-					// Incomplete JSON, wait for more data
-					break
-				}
-				g.log.Errorf("parsing response: %v", err)
-				buffer.Reset() // Clear buffer to avoid corrupted state
-				break
+// readResponses reads responses from GSPro until the connection fails.
+func (g *Simulator) readResponses(conn net.Conn) {
+	defer func() { // Recover from unexpected errors
+		if r := recover(); r != nil {
+			g.log.Errorf("panic reading response: %v", r)
+		}
+	}()
+
+	// GSPro sends plain JSON objects, which a streaming decoder handles whether or not they are newline-delimited.
+	decoder := json.NewDecoder(conn)
+	for {
+		var resp GSProResponse
+		if err := decoder.Decode(&resp); err != nil {
+			if errors.Is(err, io.EOF) {
+				g.log.Warnf("connection closed by GSPro.")
+			} else if !errors.Is(err, net.ErrClosed) {
+				g.log.Errorf("reading response: %v", err)
 			}
+			return
+		}
 
-			g.log.Infof("✅ GSPro Response: Code=%d, Message=%s, Player=%+v", resp.Code, resp.Message, resp.Player)
+		g.log.Infof("✅ GSPro Response: Code=%d, Message=%s, Player=%+v", resp.Code, resp.Message, resp.Player)
+		g.acknowledge(resp)
 
-			buffer.Reset()
+		response := ConvertResponseToStandard(resp, g.Units)
+		g.responseMutex.Lock()
+		if response.Player != nil {
+			g.lastPlayer = &response
+		}
+		callback := g.onResponse
+		g.responseMutex.Unlock()
+		if callback != nil {
+			callback(response)
 		}
 	}
 }
 
-// LaunchShot sends a shot message to GSPro.
+// LaunchShot sends a shot message to GSPro in the configured units and waits for GSPro to acknowledge or reject it.
 func (g *Simulator) LaunchShot(ballData Shared.StandardizedBallData, clubData Shared.StandardizedClubData, shotDataOptions Shared.ShotDataOptions) error {
 
-	ballOut, clubOut := ConvertToSimulator(ballData, clubData)
+	ballOut, clubOut := ConvertToSimulator(
+		Shared.ConvertBallUnits(ballData, Shared.StandardUnits, g.Units),
+		Shared.ConvertClubUnits(clubData, Shared.StandardUnits, g.Units),
+	)
 
 	shot := ShotMessage{
 		DeviceID:        g.DeviceID,
-		Units:           g.Units,
+		Units:           ConvertUnitsToSimulator(g.Units),
 		ShotNumber:      atomic.AddInt32(g.ShotNumber, 1),
 		APIversion:      g.APIVersion,
 		BallData:        ballOut,
 		ClubData:        clubOut,
 		ShotDataOptions: shotDataOptions,
 	}
-	data, err := json.Marshal(shot)
-	if err != nil {
-		g.log.Errorf("marshaling shot message: %v", err)
+	p, err := g.send(shot, shotDataOptions.IsHeartBeat)
+	if err != nil || p == nil {
 		return err
 	}
-	g.log.Infof("sending shot: %s", data)
+	return g.wait(p)
+}
+
+// send writes a shot, or queues it when GSPro is not connected.
+// It returns the tracked shot to wait for, which is nil for heartbeats or when acknowledgements are not awaited.
+func (g *Simulator) send(shot ShotMessage, isHeartBeat bool) (*pendingShot, error) {
+	g.connMutex.Lock()
+	defer g.connMutex.Unlock()
+
 	if g.conn == nil {
-		g.log.Errorf("no connection to GSPro")
-		return fmt.Errorf("no connection")
+		if isHeartBeat {
+			return nil, fmt.Errorf("no connection")
+		}
+		g.enqueue(shot)
+		return nil, Simulators.ErrShotQueued
 	}
-	// Write JSON followed by newline.
-	_, err = g.conn.Write(append(data, '\n'))
-	if err != nil {
+
+	var p *pendingShot
+	if !isHeartBeat {
+		p = g.track(shot.ShotNumber)
+	}
+	if err := g.write(shot); err != nil {
 		g.log.Errorf("sending shot message: %v", err)
+		g.untrack(p)
+		// Close the connection so the read loop reconnects, and keep the shot for then.
+		_ = g.conn.Close()
+		g.conn = nil
+		if isHeartBeat {
+			return nil, err
+		}
+		g.enqueue(shot)
+		return nil, Simulators.ErrShotQueued
+	}
+	if !isHeartBeat {
+		g.log.Infof("shot sent successfully")
+	}
+	return p, nil
+}
+
+// write sends one shot message. The caller must hold connMutex.
+func (g *Simulator) write(shot ShotMessage) error {
+	data, err := json.Marshal(shot)
+	if err != nil {
+		return fmt.Errorf("marshaling shot message: %w", err)
+	}
+	g.log.Infof("sending shot: %s", data)
+	// Write JSON followed by newline.
+	if _, err := g.conn.Write(append(data, '\n')); err != nil {
 		return err
 	}
-	g.log.Infof("shot sent successfully")
 	return nil
 }
 
-// Close closes the connection.
+// enqueue keeps a shot until GSPro reconnects. The caller must hold connMutex.
+func (g *Simulator) enqueue(shot ShotMessage) {
+	g.queue = append(g.queue, queuedShot{Shot: shot, QueuedAt: time.Now()})
+	g.pruneQueue()
+	g.saveQueue()
+	g.log.Warnf("GSPro is not connected, queued shot %d (%d queued)", shot.ShotNumber, len(g.queue))
+}
+
+// Convention returns GSPro's native data convention, which is the standard convention.
+func (g *Simulator) Convention() Shared.Convention {
+	return Shared.StandardConvention
+}
+
+// SetOnResponseCallback sets the callback function for GSPro responses.
+// GSPro sends the player state as soon as it connects, so the last player update is passed on straight away.
+func (g *Simulator) SetOnResponseCallback(callback Shared.ResponseHandlerFunc) {
+	g.responseMutex.Lock()
+	g.onResponse = callback
+	last := g.lastPlayer
+	g.responseMutex.Unlock()
+	if callback != nil && last != nil {
+		callback(*last)
+	}
+}
+
+// Close closes the connection and stops reconnecting.
 func (g *Simulator) Close() error {
 	close(g.shutdownChan)
+	g.connMutex.Lock()
+	defer g.connMutex.Unlock()
 	if g.conn != nil {
 		g.log.Infof("disconnecting from GSPro")
 		err := g.conn.Close()
@@ -0,0 +1,132 @@
+package GSPro
+
+import (
+	"Fairway_Bridge/Simulators"
+	"errors"
+	"time"
+)
+
+// codePlayerInfo is the response code GSPro uses for player updates, which do not answer a shot.
+const codePlayerInfo = 201
+
+// errConnectionLost is reported for shots that were sent but not answered before the connection dropped.
+var errConnectionLost = errors.New("connection to GSPro lost before the shot was acknowledged")
+
+// pendingShot is a sent shot waiting for GSPro's answer.
+// answered and expired are guarded by the simulator's pendingMutex.
+type pendingShot struct {
+	number   int32
+	done     chan error
+	answered bool
+	// expired is set when the shot timed out; it stays queued so GSPro's late answer is matched to it.
+	expired bool
+}
+
+// track registers a shot before it is written, so a fast answer is not missed.
+// GSPro's answers carry no shot number, so they are matched to shots in the order the shots were sent.
+// Heartbeats are not tracked as GSPro does not answer them.
+// It returns nil when acknowledgements are not awaited.
+func (g *Simulator) track(number int32) *pendingShot {
+	if g.ackTimeout <= 0 {
+		return nil
+	}
+	g.pendingMutex.Lock()
+	defer g.pendingMutex.Unlock()
+
+	p := &pendingShot{number: number, done: make(chan error, 1)}
+	g.pending = append(g.pending, p)
+	return p
+}
+
+// untrack stops waiting for an answer to a shot that could not be written.
+func (g *Simulator) untrack(p *pendingShot) {
+	if p == nil {
+		return
+	}
+	g.pendingMutex.Lock()
+	defer g.pendingMutex.Unlock()
+	for i, q := range g.pending {
+		if q == p {
+			g.pending = append(g.pending[:i], g.pending[i+1:]...)
+			return
+		}
+	}
+}
+
+// wait blocks until GSPro answers the shot or the acknowledgement timeout passes.
+// A shot that timed out keeps its place in the queue, so a late answer is consumed by it
+// instead of being credited to the next shot.
+func (g *Simulator) wait(p *pendingShot) error {
+	select {
+	case err := <-p.done:
+		return err
+	case <-time.After(g.ackTimeout):
+	}
+
+	g.pendingMutex.Lock()
+	if p.answered {
+		// The answer arrived as the timeout passed.
+		g.pendingMutex.Unlock()
+		return <-p.done
+	}
+	p.expired = true
+	g.pendingMutex.Unlock()
+	g.log.Warnf("GSPro did not acknowledge shot %d within %s", p.number, g.ackTimeout)
+	return Simulators.ErrShotNotAcknowledged
+}
+
+// acknowledge matches a response to the oldest shot waiting for an answer.
+func (g *Simulator) acknowledge(resp GSProResponse) {
+	if resp.Code == codePlayerInfo {
+		return
+	}
+
+	g.pendingMutex.Lock()
+	if len(g.pending) == 0 {
+		g.pendingMutex.Unlock()
+		g.log.Debugf("no shot waiting for response %d", resp.Code)
+		return
+	}
+	p := g.pending[0]
+	g.pending = g.pending[1:]
+	p.answered = true
+	expired := p.expired
+	g.pendingMutex.Unlock()
+
+	if expired {
+		g.log.Warnf("shot %d was answered with %d after it timed out", p.number, resp.Code)
+		return
+	}
+
+	var err error
+	if resp.Code >= 300 {
+		err = &Simulators.ShotRejectedError{Code: resp.Code, Message: resp.Message}
+	}
+	switch {
+	case err != nil:
+		g.log.Errorf("shot %d: %v", p.number, err)
+	default:
+		g.log.Infof("✅ shot %d acknowledged", p.number)
+	}
+	p.done <- err
+}
+
+// failPending fails every shot still waiting for an answer, e.g. when the connection drops.
+// Shots that already timed out are dropped as their answer will not come.
+func (g *Simulator) failPending(err error) {
+	g.pendingMutex.Lock()
+	var pending []*pendingShot
+	for _, p := range g.pending {
+		p.answered = true
+		if !p.expired {
+			pending = append(pending, p)
+		}
+	}
+	g.pending = nil
+	g.pendingMutex.Unlock()
+
+	for _, p := range pending {
+		g.log.Warnf("shot %d: %v", p.number, err)
+		p.done <- err
+	}
+}
@@ -1,6 +1,9 @@
 package GSPro
 
-import "Fairway_Bridge/Shared"
+import (
+	"Fairway_Bridge/Shared"
+	"strings"
+)
 
 // BallData corresponds to GSPro Ball Data.
 type BallData struct {
@@ -54,6 +57,73 @@ type PlayerInfo struct {
 	Surface          string  `json:"Surface"`
 }
 
+// clubCodes maps the club codes GSPro reports in PlayerInfo to club types.
+var clubCodes = map[string]Shared.ClubType{
+	"DR": Shared.Driver,
+	"W3": Shared.ThreeWood,
+	"W5": Shared.FiveWood,
+	"W7": Shared.SevenWood,
+	"H2": Shared.TwoHybrid,
+	"H3": Shared.ThreeHybrid,
+	"H4": Shared.FourHybrid,
+	"H5": Shared.FiveHybrid,
+	"H6": Shared.SixHybrid,
+	"I1": Shared.OneIron,
+	"I2": Shared.TwoIron,
+	"I3": Shared.ThreeIron,
+	"I4": Shared.FourIron,
+	"I5": Shared.FiveIron,
+	"I6": Shared.SixIron,
+	"I7": Shared.SevenIron,
+	"I8": Shared.EightIron,
+	"I9": Shared.NineIron,
+	"PW": Shared.PitchingWedge,
+	"GW": Shared.GapWedge,
+	"SW": Shared.SandWedge,
+	"LW": Shared.LobWedge,
+	"PT": Shared.Putter,
+}
+
+// unitLabels maps unit systems to the Units GSPro's Open Connect API uses for them.
+var unitLabels = map[Shared.UnitSystem]string{
+	Shared.Imperial: "Yards",
+	Shared.Metric:   "Meters",
+}
+
+// ConvertUnitsToStandard converts a GSPro Units value such as "Meters" to a unit system.
+// Anything other than metres is taken as yards, GSPro's default.
+func ConvertUnitsToStandard(units string) Shared.UnitSystem {
+	switch strings.ToUpper(units) {
+	case "METERS", "METRES":
+		return Shared.Metric
+	default:
+		return Shared.Imperial
+	}
+}
+
+// ConvertUnitsToSimulator converts a unit system to its GSPro Units value.
+func ConvertUnitsToSimulator(units Shared.UnitSystem) string {
+	if label, ok := unitLabels[units]; ok {
+		return label
+	}
+	return unitLabels[Shared.Imperial]
+}
+
+// ConvertClubToStandard converts a GSPro club code such as "I7" to a club type, or "" when it is unknown.
+func ConvertClubToStandard(code string) Shared.ClubType {
+	return clubCodes[code]
+}
+
+// ConvertClubToSimulator converts a club type to its GSPro club code, or "" when GSPro has no such club.
+func ConvertClubToSimulator(clubType Shared.ClubType) string {
+	for code, ct := range clubCodes {
+		if ct == clubType {
+			return code
+		}
+	}
+	return ""
+}
+
 // ConvertToStandard converts the simulator's BallData and ClubData to the standardized versions.
 // It returns a StandardizedBallData and StandardizedClubData from the Shared package.
 func ConvertToStandard(ball BallData, club ClubData) (Shared.StandardizedBallData, Shared.StandardizedClubData) {
@@ -112,3 +182,41 @@ func ConvertToSimulator(ball Shared.StandardizedBallData, club Shared.Standardiz
 
 	return simBall, simClub
 }
+
+// ConvertResponseToStandard converts a GSPro response in the given units to the standardized simulator response.
+func ConvertResponseToStandard(resp GSProResponse, units Shared.UnitSystem) Shared.SimulatorResponse {
+	standardResp := Shared.SimulatorResponse{
+		Code:    resp.Code,
+		Message: resp.Message,
+	}
+	if resp.Player != nil {
+		var handed Shared.Handedness
+		if resp.Player.Handed != "" {
+			handed = Shared.ParseHandedness(resp.Player.Handed)
+		}
+		standardResp.Player = &Shared.PlayerInfo{
+			Handed:           handed,
+			Club:             ConvertClubToStandard(resp.Player.Club),
+			DistanceToTarget: Shared.ConvertDistance(resp.Player.DistanceToTarget, units, Shared.StandardUnits),
+			Surface:          resp.Player.Surface,
+		}
+	}
+	return standardResp
+}
+
+// ConvertResponseToSimulator converts a standardized simulator response back to a GSPro response in the given units.
+func ConvertResponseToSimulator(resp Shared.SimulatorResponse, units Shared.UnitSystem) GSProResponse {
+	simResp := GSProResponse{
+		Code:    resp.Code,
+		Message: resp.Message,
+	}
+	if resp.Player != nil {
+		simResp.Player = &PlayerInfo{
+			Handed:           string(resp.Player.Handed),
+			Club:             ConvertClubToSimulator(resp.Player.Club),
+			DistanceToTarget: Shared.ConvertDistance(resp.Player.DistanceToTarget, Shared.StandardUnits, units),
+			Surface:          resp.Player.Surface,
+		}
+	}
+	return simResp
+}
@@ -0,0 +1,83 @@
+package GSPro
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"sync/atomic"
+	"time"
+)
+
+// queuedShot is a shot waiting for GSPro to reconnect.
+type queuedShot struct {
+	Shot     ShotMessage `json:"Shot"`
+	QueuedAt time.Time   `json:"QueuedAt"`
+}
+
+// pruneQueue drops shots older than the maximum age and returns how many were dropped.
+// The caller must hold connMutex.
+func (g *Simulator) pruneQueue() int {
+	if g.queueMaxAge <= 0 {
+		return 0
+	}
+	cutoff := time.Now().Add(-g.queueMaxAge)
+	kept := g.queue[:0]
+	for _, q := range g.queue {
+		if q.QueuedAt.After(cutoff) {
+			kept = append(kept, q)
+		} else {
+			g.log.Warnf("dropping shot %d queued at %s, it is older than %s", q.Shot.ShotNumber, q.QueuedAt.Format(time.RFC3339), g.queueMaxAge)
+		}
+	}
+	dropped := len(g.queue) - len(kept)
+	g.queue = kept
+	return dropped
+}
+
+// loadQueue reads shots left in the queue file by a previous run.
+func (g *Simulator) loadQueue() error {
+	if g.queueFile == "" {
+		return nil
+	}
+	data, err := os.ReadFile(g.queueFile)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(data) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(data, &g.queue); err != nil {
+		return err
+	}
+	g.pruneQueue()
+	if len(g.queue) > 0 {
+		g.log.Infof("loaded %d queued shots from %s", len(g.queue), g.queueFile)
+		// Continue numbering after the queued shots so new shots do not reuse their numbers.
+		atomic.StoreInt32(g.ShotNumber, g.queue[len(g.queue)-1].Shot.ShotNumber)
+	}
+	return nil
+}
+
+// saveQueue writes the queue to the queue file so queued shots survive a restart.
+// The caller must hold connMutex.
+func (g *Simulator) saveQueue() {
+	if g.queueFile == "" {
+		return
+	}
+	data, err := json.Marshal(g.queue)
+	if err != nil {
+		g.log.Errorf("marshaling shot queue: %v", err)
+		return
+	}
+	tmp := g.queueFile + ".tmp"
+	if err := os.WriteFile(tmp, data, 0644); err != nil {
+		g.log.Errorf("saving shot queue: %v", err)
+		return
+	}
+	if err := os.Rename(tmp, g.queueFile); err != nil {
+		g.log.Errorf("saving shot queue: %v", err)
+	}
+}
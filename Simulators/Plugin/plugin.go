@@ -0,0 +1,196 @@
+package Plugin
+
+import (
+	"Fairway_Bridge/Shared"
+	"Fairway_Bridge/Simulators"
+	"errors"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// errPluginExited is reported for shots that were sent but not answered before the plugin exited.
+var errPluginExited = errors.New("plugin exited before answering the shot")
+
+// Simulator sends shots to an external plugin process over its stdin and reads its answers from stdout.
+// The plugin is restarted when it exits, so a crash only fails the shots in flight.
+type Simulator struct {
+	process      *Shared.PluginProcess
+	ackTimeout   time.Duration
+	units        Shared.UnitSystem
+	nextID       int
+	pending      map[int]chan Shared.PluginMessage
+	pendingMutex sync.Mutex
+	lastResult   *Shared.ShotResult
+	state        Shared.ConnectionState
+	stateSince   time.Time
+	stateMutex   sync.Mutex
+	log          *zap.SugaredLogger
+	onResponse   Shared.ResponseHandlerFunc
+	onConnection Shared.ConnectionHandlerFunc
+}
+
+// NewSimulator creates a simulator that runs the configured plugin command.
+func NewSimulator(logger *zap.Logger, config Shared.Config) *Simulator {
+	log := logger.With(zap.String("component", "SIMULATOR"), zap.String("type", "PLUGIN")).Sugar()
+	s := &Simulator{
+		ackTimeout: time.Duration(config.Simulator.AckTimeoutSeconds) * time.Second,
+		units:      config.Simulator.Units,
+		pending:    map[int]chan Shared.PluginMessage{},
+		state:      Shared.Connecting,
+		stateSince: time.Now(),
+		log:        log,
+	}
+	s.process = Shared.NewPluginProcess(config.Plugin.SimulatorCommand, "Simulator", config.Simulator.Units, log, s.handleMessage, s.setState)
+	return s
+}
+
+// Connect starts the plugin process.
+func (s *Simulator) Connect() error {
+	return s.process.Start()
+}
+
+// LaunchShot sends the shot to the plugin in the configured units and waits for its Result, up to the acknowledgement timeout.
+// A Result with a code of 300 or more is returned as a Simulators.ShotRejectedError.
+func (s *Simulator) LaunchShot(ballData Shared.StandardizedBallData, clubData Shared.StandardizedClubData, shotDataOptions Shared.ShotDataOptions) error {
+	s.pendingMutex.Lock()
+	s.nextID++
+	id := s.nextID
+	done := make(chan Shared.PluginMessage, 1)
+	s.pending[id] = done
+	s.pendingMutex.Unlock()
+	defer s.untrack(id)
+
+	ballData = Shared.ConvertBallUnits(ballData, Shared.StandardUnits, s.units)
+	clubData = Shared.ConvertClubUnits(clubData, Shared.StandardUnits, s.units)
+	msg := Shared.PluginMessage{Type: Shared.PluginShot, ID: id, Units: s.units, BallData: &ballData, ShotDataOptions: &shotDataOptions}
+	if shotDataOptions.ContainsClubData {
+		msg.ClubData = &clubData
+	}
+	if err := s.process.Send(msg); err != nil {
+		return err
+	}
+	if s.ackTimeout <= 0 {
+		return nil
+	}
+
+	select {
+	case result, ok := <-done:
+		if !ok {
+			return errPluginExited
+		}
+		return s.answer(result)
+	case <-time.After(s.ackTimeout):
+		s.log.Warnf("plugin did not answer shot %d within %s", id, s.ackTimeout)
+		return Simulators.ErrShotNotAcknowledged
+	}
+}
+
+// answer records a plugin's Result, in the configured units, and turns it into LaunchShot's error.
+func (s *Simulator) answer(result Shared.PluginMessage) error {
+	if result.Code == 0 {
+		result.Code = 200
+	}
+	if result.Result != nil {
+		standard := Shared.ConvertShotResultUnits(*result.Result, s.units, Shared.StandardUnits)
+		result.Result = &standard
+	}
+	s.pendingMutex.Lock()
+	s.lastResult = result.Result
+	s.pendingMutex.Unlock()
+
+	if s.onResponse != nil {
+		s.onResponse(Shared.SimulatorResponse{Code: result.Code, Message: result.Message})
+	}
+	if result.Code >= 300 {
+		return &Simulators.ShotRejectedError{Code: result.Code, Message: result.Message}
+	}
+	return nil
+}
+
+// untrack stops waiting for an answer to a shot.
+func (s *Simulator) untrack(id int) {
+	s.pendingMutex.Lock()
+	defer s.pendingMutex.Unlock()
+	delete(s.pending, id)
+}
+
+// handleMessage passes Result messages to the shot they answer.
+func (s *Simulator) handleMessage(msg Shared.PluginMessage) {
+	if msg.Type != Shared.PluginResult {
+		s.log.Warnf("ignoring %s message from simulator plugin", msg.Type)
+		return
+	}
+	s.pendingMutex.Lock()
+	done, ok := s.pending[msg.ID]
+	delete(s.pending, msg.ID)
+	s.pendingMutex.Unlock()
+	if !ok {
+		s.log.Warnf("plugin answered unknown or expired shot %d", msg.ID)
+		return
+	}
+	done <- msg
+}
+
+// setState records the plugin's state and fails the shots waiting on a plugin that exited.
+func (s *Simulator) setState(state Shared.ConnectionState) {
+	if state == Shared.Disconnected {
+		s.pendingMutex.Lock()
+		for id, done := range s.pending {
+			close(done)
+			delete(s.pending, id)
+		}
+		s.pendingMutex.Unlock()
+	}
+
+	s.stateMutex.Lock()
+	s.state = state
+	s.stateSince = time.Now()
+	status := Shared.ConnectionStatus{State: s.state, Since: s.stateSince}
+	callback := s.onConnection
+	s.stateMutex.Unlock()
+	if callback != nil {
+		callback(status)
+	}
+}
+
+// ConnectionStatus reports whether the plugin process is running.
+func (s *Simulator) ConnectionStatus() Shared.ConnectionStatus {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+	return Shared.ConnectionStatus{State: s.state, Since: s.stateSince}
+}
+
+// SetOnConnectionCallback sets the callback function to be called when the plugin starts or exits.
+func (s *Simulator) SetOnConnectionCallback(f Shared.ConnectionHandlerFunc) {
+	s.stateMutex.Lock()
+	defer s.stateMutex.Unlock()
+	s.onConnection = f
+}
+
+// LastShotResult returns the result the plugin reported with its last answer, if any.
+func (s *Simulator) LastShotResult() (Shared.ShotResult, bool) {
+	s.pendingMutex.Lock()
+	defer s.pendingMutex.Unlock()
+	if s.lastResult == nil {
+		return Shared.ShotResult{}, false
+	}
+	return *s.lastResult, true
+}
+
+// Convention returns the standard convention; plugins receive shots as the Router standardizes them.
+func (s *Simulator) Convention() Shared.Convention {
+	return Shared.StandardConvention
+}
+
+// SetOnResponseCallback sets the callback function to be called with the plugin's answer to each shot.
+func (s *Simulator) SetOnResponseCallback(f Shared.ResponseHandlerFunc) {
+	s.onResponse = f
+}
+
+// Close asks the plugin to exit and stops restarting it.
+func (s *Simulator) Close() error {
+	s.process.Stop()
+	return nil
+}
@@ -2,28 +2,44 @@ package Virtual
 
 import (
 	"Fairway_Bridge/Shared"
-	"go.uber.org/zap"
+	"sync"
 	"time"
+
+	"go.uber.org/zap"
 )
 
-// Simulator creates a virtual golf simulator.
+// Simulator is a virtual golf simulator that flies each shot with a ball-flight model,
+// giving a practice range without a simulator licence.
 type Simulator struct {
-	log *zap.SugaredLogger
+	log        *zap.SugaredLogger
+	config     Shared.Config
+	model      Shared.FlightModel
+	onResponse Shared.ResponseHandlerFunc
+
+	lastResult      Shared.ShotResult
+	hasResult       bool
+	lastResultMutex sync.Mutex
 }
 
 // NewSimulator initializes the virtual simulator.
-func NewSimulator(logger *zap.Logger) *Simulator {
+func NewSimulator(logger *zap.Logger, config Shared.Config) *Simulator {
 	log := logger.With(zap.String("component", "SIMULATOR"), zap.String("type", "VIRTUAL")).Sugar()
 	return &Simulator{
-		log: log,
+		log:    log,
+		config: config,
 	}
 }
 
-// Connect establishes a connection.
+// Connect sets up the ball-flight model from the configured ground and spin decay.
 func (vs *Simulator) Connect() error {
 	vs.log.Infof("connecting to virtual simulator...")
+	ground, err := Shared.ParseGround(vs.config.VirtualSimulator.Ground)
+	if err != nil {
+		return err
+	}
+	vs.model = Shared.FlightModel{SpinDecay: vs.config.VirtualSimulator.SpinDecay, Ground: ground}
 	time.Sleep(200 * time.Millisecond) // Simulate delay
-	vs.log.Infof("✅ connected to virtual simulator!")
+	vs.log.Infof("✅ connected to virtual simulator on %s ground!", vs.config.VirtualSimulator.Ground)
 	return nil
 }
 
@@ -35,10 +51,45 @@ func (vs *Simulator) Close() error {
 	return nil
 }
 
-// LaunchShot simulates launching a golf shot.
+// LaunchShot flies the shot with the ball-flight model and keeps the result for the Router.
 func (vs *Simulator) LaunchShot(ballData Shared.StandardizedBallData, cludData Shared.StandardizedClubData, shotDataOptions Shared.ShotDataOptions) error {
-	vs.log.Infof("🏌️ simulating shot launch...")
-	time.Sleep(1 * time.Second) // Simulate processing time
-	vs.log.Infof("⛳️ shot launched on virtual simulator! %v %v %v ", ballData, cludData, shotDataOptions)
+	result := vs.model.Simulate(ballData, Shared.ClubType(shotDataOptions.ClubType))
+
+	vs.lastResultMutex.Lock()
+	vs.lastResult = result
+	vs.hasResult = true
+	vs.lastResultMutex.Unlock()
+
+	// The result is kept in standard units and only logged in the configured ones.
+	units := vs.config.Simulator.Units
+	shown := Shared.ConvertShotResultUnits(result, Shared.StandardUnits, units)
+	vs.log.Infof("⛳️ %s: carry %.1f, total %.1f, apex %.1f, offline %.1f (%s), descent %.1f°, flight time %.1fs",
+		shotDataOptions.ClubType, shown.CarryDistance, shown.TotalDistance, shown.Apex,
+		shown.TotalDeviation, units.DistanceUnit(), result.DescentAngle, result.FlightTime)
+
+	// Acknowledge the shot the same way a real simulator would.
+	if vs.onResponse != nil {
+		vs.onResponse(Shared.SimulatorResponse{
+			Code:    200,
+			Message: "Shot received successfully",
+		})
+	}
 	return nil
 }
+
+// LastShotResult returns the modelled result of the last shot.
+func (vs *Simulator) LastShotResult() (Shared.ShotResult, bool) {
+	vs.lastResultMutex.Lock()
+	defer vs.lastResultMutex.Unlock()
+	return vs.lastResult, vs.hasResult
+}
+
+// Convention returns the virtual simulator's native data convention.
+func (vs *Simulator) Convention() Shared.Convention {
+	return Shared.StandardConvention
+}
+
+// SetOnResponseCallback sets the callback function to be called when the simulator responds.
+func (vs *Simulator) SetOnResponseCallback(f Shared.ResponseHandlerFunc) {
+	vs.onResponse = f
+}
@@ -0,0 +1,64 @@
+package Webhook
+
+import (
+	"Fairway_Bridge/Shared"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/template"
+	"time"
+)
+
+// Payload is the shot posted to the webhook, and the data available to a body template.
+// Speeds and distances are in Units.
+type Payload struct {
+	ShotNumber int                          `json:"ShotNumber"`
+	Timestamp  time.Time                    `json:"Timestamp"`
+	ClubType   string                       `json:"ClubType,omitempty"`
+	Handed     Shared.Handedness            `json:"Handed"`
+	Units      Shared.UnitSystem            `json:"Units"`
+	BallData   Shared.StandardizedBallData  `json:"BallData"`
+	ClubData   *Shared.StandardizedClubData `json:"ClubData,omitempty"`
+}
+
+// templateFuncs are the functions available to body templates in addition to the text/template builtins.
+var templateFuncs = template.FuncMap{
+	// json renders a value as JSON, e.g. {{json .BallData}}.
+	"json": func(v interface{}) (string, error) {
+		data, err := json.Marshal(v)
+		return string(data), err
+	},
+	// round rounds a number to the given number of decimals, e.g. {{round .BallData.Speed 1}}.
+	"round": func(v float64, decimals int) string {
+		return fmt.Sprintf("%.*f", decimals, v)
+	},
+}
+
+// loadTemplate parses the body template file; no file means the payload is posted as JSON.
+func loadTemplate(path string) (*template.Template, error) {
+	if path == "" {
+		return nil, nil
+	}
+	text, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	tmpl, err := template.New("body").Funcs(templateFuncs).Option("missingkey=error").Parse(string(text))
+	if err != nil {
+		return nil, fmt.Errorf("parsing template %s: %w", path, err)
+	}
+	return tmpl, nil
+}
+
+// render builds the request body for a payload.
+func render(tmpl *template.Template, payload Payload) ([]byte, error) {
+	if tmpl == nil {
+		return json.Marshal(payload)
+	}
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, payload); err != nil {
+		return nil, fmt.Errorf("rendering template: %w", err)
+	}
+	return body.Bytes(), nil
+}
@@ -0,0 +1,215 @@
+package Webhook
+
+import (
+	"Fairway_Bridge/Shared"
+	"Fairway_Bridge/Simulators"
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"text/template"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// SignatureHeader carries the HMAC-SHA256 of the request body, as "sha256=<hex>", when a secret is set.
+	SignatureHeader = "X-Fairway-Signature"
+
+	// minRetryDelay is the delay before the first retry; it doubles for each further retry.
+	minRetryDelay = 500 * time.Millisecond
+	// maxMessageBytes is how much of a failed response's body is kept for the delivery message.
+	maxMessageBytes = 200
+)
+
+// Simulator posts each shot to an HTTP endpoint, e.g. a coaching app, spreadsheet or home-automation hook.
+type Simulator struct {
+	url          string
+	templateFile string
+	headerList   string
+	headers      map[string]string
+	secret       []byte
+	retries      int
+	units        Shared.UnitSystem
+	client       *http.Client
+	body         *template.Template
+	shotNumber   int32
+	log          *zap.SugaredLogger
+	onResponse   Shared.ResponseHandlerFunc
+	shutdownChan chan struct{}
+}
+
+// NewSimulator creates a webhook simulator from the configuration.
+func NewSimulator(logger *zap.Logger, config Shared.Config) *Simulator {
+	log := logger.With(zap.String("component", "SIMULATOR"), zap.String("type", "WEBHOOK")).Sugar()
+	return &Simulator{
+		url:          config.Webhook.URL,
+		templateFile: config.Webhook.TemplateFile,
+		secret:       []byte(config.Webhook.Secret),
+		retries:      config.Webhook.Retries,
+		units:        config.Simulator.Units,
+		client:       &http.Client{Timeout: time.Duration(config.Webhook.TimeoutSeconds) * time.Second},
+		headerList:   config.Webhook.Headers,
+		log:          log,
+		shutdownChan: make(chan struct{}),
+	}
+}
+
+// parseHeaders parses request headers from a comma-separated list of Name=Value pairs.
+func parseHeaders(list string) (map[string]string, error) {
+	headers := map[string]string{}
+	for _, pair := range strings.Split(list, ",") {
+		if strings.TrimSpace(pair) == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(pair, "=")
+		name = strings.TrimSpace(name)
+		if !ok || name == "" {
+			return nil, fmt.Errorf("header %q is not Name=Value", pair)
+		}
+		headers[http.CanonicalHeaderKey(name)] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
+
+// Connect checks the URL and headers and loads the body template. Nothing is sent until the first shot.
+func (w *Simulator) Connect() error {
+	if !strings.HasPrefix(w.url, "http://") && !strings.HasPrefix(w.url, "https://") {
+		return fmt.Errorf("webhook URL %q must start with http:// or https://", w.url)
+	}
+	headers, err := parseHeaders(w.headerList)
+	if err != nil {
+		return err
+	}
+	w.headers = headers
+	body, err := loadTemplate(w.templateFile)
+	if err != nil {
+		return err
+	}
+	w.body = body
+	w.log.Infof("✅ posting shots to %s", w.url)
+	return nil
+}
+
+// LaunchShot posts the shot, retrying on network errors, 429 and 5xx responses.
+// Other 4xx responses are returned as a Simulators.ShotRejectedError without retrying.
+func (w *Simulator) LaunchShot(ballData Shared.StandardizedBallData, clubData Shared.StandardizedClubData, shotDataOptions Shared.ShotDataOptions) error {
+	if shotDataOptions.IsHeartBeat {
+		return nil
+	}
+
+	payload := Payload{
+		ShotNumber: int(atomic.AddInt32(&w.shotNumber, 1)),
+		Timestamp:  time.Now(),
+		ClubType:   shotDataOptions.ClubType,
+		Handed:     Shared.GetHandedness(),
+		Units:      w.units,
+		BallData:   Shared.ConvertBallUnits(ballData, Shared.StandardUnits, w.units),
+	}
+	if shotDataOptions.ContainsClubData {
+		clubData = Shared.ConvertClubUnits(clubData, Shared.StandardUnits, w.units)
+		payload.ClubData = &clubData
+	}
+	body, err := render(w.body, payload)
+	if err != nil {
+		return err
+	}
+
+	delay := minRetryDelay
+	for attempt := 0; ; attempt++ {
+		retry, err := w.post(body)
+		if err == nil {
+			w.log.Infof("⛳️ shot %d posted", payload.ShotNumber)
+			w.respond(http.StatusOK, "Shot received successfully")
+			return nil
+		}
+		if !retry || attempt >= w.retries {
+			var rejected *Simulators.ShotRejectedError
+			if errors.As(err, &rejected) {
+				w.respond(rejected.Code, rejected.Message)
+			}
+			return err
+		}
+		w.log.Warnf("posting shot %d: %v, retrying in %v", payload.ShotNumber, err, delay)
+		select {
+		case <-time.After(delay):
+		case <-w.shutdownChan:
+			return err
+		}
+		delay *= 2
+	}
+}
+
+// post sends one request and reports whether a failure is worth retrying.
+func (w *Simulator) post(body []byte) (bool, error) {
+	req, err := http.NewRequest(http.MethodPost, w.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Fairway-Bridge/"+Shared.Version)
+	for name, value := range w.headers {
+		req.Header.Set(name, value)
+	}
+	if len(w.secret) > 0 {
+		req.Header.Set(SignatureHeader, Sign(w.secret, body))
+	}
+
+	resp, err := w.client.Do(req)
+	if err != nil {
+		var timeout interface{ Timeout() bool }
+		if errors.As(err, &timeout) && timeout.Timeout() {
+			return true, fmt.Errorf("%w: %v", Simulators.ErrShotNotAcknowledged, err)
+		}
+		return true, err
+	}
+	defer resp.Body.Close()
+	message, _ := io.ReadAll(io.LimitReader(resp.Body, maxMessageBytes))
+	if resp.StatusCode < 300 {
+		return false, nil
+	}
+	rejected := &Simulators.ShotRejectedError{Code: resp.StatusCode, Message: strings.TrimSpace(string(message))}
+	if rejected.Message == "" {
+		rejected.Message = http.StatusText(resp.StatusCode)
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500, rejected
+}
+
+// respond passes the final answer to a shot to the response callback.
+func (w *Simulator) respond(code int, message string) {
+	if w.onResponse != nil {
+		w.onResponse(Shared.SimulatorResponse{Code: code, Message: message})
+	}
+}
+
+// Sign returns the signature header value for a body: the hex HMAC-SHA256 of the body, prefixed with "sha256=".
+// Receivers should compute the same value and compare it with hmac.Equal.
+func Sign(secret []byte, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// Convention returns the standard convention; shots are posted as the Router standardizes them.
+func (w *Simulator) Convention() Shared.Convention {
+	return Shared.StandardConvention
+}
+
+// SetOnResponseCallback sets the callback function to be called with the answer to each shot.
+func (w *Simulator) SetOnResponseCallback(f Shared.ResponseHandlerFunc) {
+	w.onResponse = f
+}
+
+// Close stops any retry in progress.
+func (w *Simulator) Close() error {
+	close(w.shutdownChan)
+	w.client.CloseIdleConnections()
+	return nil
+}
@@ -0,0 +1,259 @@
+package Webhook
+
+import (
+	"Fairway_Bridge/Shared"
+	"Fairway_Bridge/Simulators"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// request is a request received by the test server.
+type request struct {
+	header http.Header
+	body   []byte
+}
+
+// testServer records the requests it receives and answers each with respond.
+type testServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	requests []request
+}
+
+func newTestServer(t *testing.T, respond func(w http.ResponseWriter, r *http.Request)) *testServer {
+	t.Helper()
+	s := &testServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		s.mu.Lock()
+		s.requests = append(s.requests, request{header: r.Header.Clone(), body: body})
+		s.mu.Unlock()
+		respond(w, r)
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *testServer) received() []request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]request(nil), s.requests...)
+}
+
+// newTestSimulator connects a webhook simulator to url with the configuration changed by configure.
+func newTestSimulator(t *testing.T, url string, configure func(config *Shared.Config)) *Simulator {
+	t.Helper()
+	config := Shared.Config{}
+	config.Simulator.Units = Shared.StandardUnits
+	config.Webhook.URL = url
+	config.Webhook.TimeoutSeconds = 5
+	if configure != nil {
+		configure(&config)
+	}
+	w := NewSimulator(zap.NewNop(), config)
+	if err := w.Connect(); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = w.Close() })
+	return w
+}
+
+var (
+	testBall    = Shared.StandardizedBallData{Speed: 150.25, TotalSpin: 2800, VLA: 12.5, HLA: -1.5}
+	testClub    = Shared.StandardizedClubData{Speed: 104, Path: 2}
+	testOptions = Shared.ShotDataOptions{ContainsBallData: true, ContainsClubData: true, ClubType: string(Shared.Driver)}
+)
+
+func respondOK(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestDefaultBody(t *testing.T) {
+	server := newTestServer(t, respondOK)
+	w := newTestSimulator(t, server.URL, nil)
+	var response Shared.SimulatorResponse
+	w.SetOnResponseCallback(func(r Shared.SimulatorResponse) { response = r })
+
+	if err := w.LaunchShot(testBall, testClub, testOptions); err != nil {
+		t.Fatal(err)
+	}
+
+	requests := server.received()
+	if len(requests) != 1 {
+		t.Fatalf("got %d requests, want 1", len(requests))
+	}
+	if got := requests[0].header.Get("Content-Type"); got != "application/json" {
+		t.Errorf("Content-Type is %q, want application/json", got)
+	}
+	var payload Payload
+	if err := json.Unmarshal(requests[0].body, &payload); err != nil {
+		t.Fatalf("body is not a payload: %v\n%s", err, requests[0].body)
+	}
+	if payload.ShotNumber != 1 || payload.ClubType != string(Shared.Driver) || payload.BallData != testBall {
+		t.Errorf("payload is %+v", payload)
+	}
+	if payload.ClubData == nil || *payload.ClubData != testClub {
+		t.Errorf("club data is %+v, want %+v", payload.ClubData, testClub)
+	}
+	if response.Code != http.StatusOK {
+		t.Errorf("response code is %d, want 200", response.Code)
+	}
+}
+
+func TestTemplateBody(t *testing.T) {
+	server := newTestServer(t, respondOK)
+	templateFile := filepath.Join(t.TempDir(), "body.tmpl")
+	text := `{"shot": {{.ShotNumber}}, "club": "{{.ClubType}}", "speed": {{round .BallData.Speed 1}}, "ball": {{json .BallData}}}`
+	if err := os.WriteFile(templateFile, []byte(text), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	w := newTestSimulator(t, server.URL, func(config *Shared.Config) {
+		config.Webhook.TemplateFile = templateFile
+	})
+
+	if err := w.LaunchShot(testBall, testClub, testOptions); err != nil {
+		t.Fatal(err)
+	}
+
+	ball, _ := json.Marshal(testBall)
+	want := `{"shot": 1, "club": "Driver", "speed": 150.2, "ball": ` + string(ball) + `}`
+	if got := string(server.received()[0].body); got != want {
+		t.Errorf("body is\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestHeadersAndSignature(t *testing.T) {
+	server := newTestServer(t, respondOK)
+	secret := "s3cret"
+	w := newTestSimulator(t, server.URL, func(config *Shared.Config) {
+		config.Webhook.Headers = "Authorization=Bearer abc, x-team = blue"
+		config.Webhook.Secret = secret
+	})
+
+	if err := w.LaunchShot(testBall, testClub, testOptions); err != nil {
+		t.Fatal(err)
+	}
+
+	received := server.received()[0]
+	if got := received.header.Get("Authorization"); got != "Bearer abc" {
+		t.Errorf("Authorization is %q, want Bearer abc", got)
+	}
+	if got := received.header.Get("X-Team"); got != "blue" {
+		t.Errorf("X-Team is %q, want blue", got)
+	}
+
+	signature := received.header.Get(SignatureHeader)
+	if signature != Sign([]byte(secret), received.body) {
+		t.Errorf("%s is %q, want %q", SignatureHeader, signature, Sign([]byte(secret), received.body))
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(received.body)
+	if want := "sha256=" + hex.EncodeToString(mac.Sum(nil)); signature != want {
+		t.Errorf("%s is %q, want the HMAC-SHA256 of the body %q", SignatureHeader, signature, want)
+	}
+}
+
+func TestUnsignedWithoutSecret(t *testing.T) {
+	server := newTestServer(t, respondOK)
+	w := newTestSimulator(t, server.URL, nil)
+
+	if err := w.LaunchShot(testBall, testClub, testOptions); err != nil {
+		t.Fatal(err)
+	}
+	if got := server.received()[0].header.Get(SignatureHeader); got != "" {
+		t.Errorf("%s is %q without a secret", SignatureHeader, got)
+	}
+}
+
+func TestRetries(t *testing.T) {
+	tests := []struct {
+		status   int
+		attempts int
+	}{
+		{http.StatusServiceUnavailable, 2},
+		{http.StatusTooManyRequests, 2},
+		{http.StatusBadRequest, 1},
+		{http.StatusNotFound, 1},
+	}
+	for _, test := range tests {
+		t.Run(http.StatusText(test.status), func(t *testing.T) {
+			server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+				http.Error(w, "nope", test.status)
+			})
+			w := newTestSimulator(t, server.URL, func(config *Shared.Config) {
+				config.Webhook.Retries = 1
+			})
+			var response Shared.SimulatorResponse
+			w.SetOnResponseCallback(func(r Shared.SimulatorResponse) { response = r })
+
+			err := w.LaunchShot(testBall, testClub, testOptions)
+
+			var rejected *Simulators.ShotRejectedError
+			if !errors.As(err, &rejected) {
+				t.Fatalf("error is %v, want a ShotRejectedError", err)
+			}
+			if rejected.Code != test.status || rejected.Message != "nope" {
+				t.Errorf("rejected with %d %q, want %d \"nope\"", rejected.Code, rejected.Message, test.status)
+			}
+			if got := len(server.received()); got != test.attempts {
+				t.Errorf("got %d attempts, want %d", got, test.attempts)
+			}
+			if response.Code != test.status {
+				t.Errorf("response code is %d, want %d", response.Code, test.status)
+			}
+		})
+	}
+}
+
+func TestRetrySucceeds(t *testing.T) {
+	var calls int
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	w := newTestSimulator(t, server.URL, func(config *Shared.Config) {
+		config.Webhook.Retries = 2
+	})
+
+	if err := w.LaunchShot(testBall, testClub, testOptions); err != nil {
+		t.Fatal(err)
+	}
+	if got := len(server.received()); got != 2 {
+		t.Errorf("got %d attempts, want 2", got)
+	}
+}
+
+func TestTimeout(t *testing.T) {
+	release := make(chan struct{})
+	server := newTestServer(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	})
+	defer close(release)
+	w := newTestSimulator(t, server.URL, nil)
+	w.client.Timeout = 50 * time.Millisecond
+
+	err := w.LaunchShot(testBall, testClub, testOptions)
+	if !errors.Is(err, Simulators.ErrShotNotAcknowledged) {
+		t.Errorf("error is %v, want ErrShotNotAcknowledged", err)
+	}
+}
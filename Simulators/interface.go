@@ -1,10 +1,60 @@
 package Simulators
 
-import "Fairway_Bridge/Shared"
+import (
+	"Fairway_Bridge/Shared"
+	"errors"
+	"fmt"
+)
 
 // SimulatorController is an interface that defines the methods required for a simulator controller.
 type SimulatorController interface {
 	Connect() error
 	LaunchShot(ballData Shared.StandardizedBallData, clubData Shared.StandardizedClubData, shotDataOptions Shared.ShotDataOptions) error
+	SetOnResponseCallback(f Shared.ResponseHandlerFunc)
+	Convention() Shared.Convention
 	Close() error
 }
+
+// ShotResultReporter is implemented by simulators that report the outcome of the shots they receive.
+type ShotResultReporter interface {
+	LastShotResult() (Shared.ShotResult, bool)
+}
+
+// ConnectionReporter is implemented by simulators that keep a connection they may lose and regain.
+type ConnectionReporter interface {
+	SetOnConnectionCallback(f Shared.ConnectionHandlerFunc)
+	ConnectionStatus() Shared.ConnectionStatus
+}
+
+// ErrShotQueued is returned by LaunchShot when the shot was queued until the simulator reconnects.
+var ErrShotQueued = errors.New("shot queued until the simulator reconnects")
+
+// ErrShotNotAcknowledged is returned by LaunchShot when the simulator did not answer a shot in time.
+var ErrShotNotAcknowledged = errors.New("simulator did not acknowledge the shot")
+
+// ShotRejectedError is returned by LaunchShot when the simulator answers a shot with an error.
+type ShotRejectedError struct {
+	Code    int
+	Message string
+}
+
+func (e *ShotRejectedError) Error() string {
+	return fmt.Sprintf("simulator rejected the shot: %d %s", e.Code, e.Message)
+}
+
+// DeliveryOf describes the outcome of a LaunchShot call from the error it returned.
+func DeliveryOf(err error) Shared.Delivery {
+	var rejected *ShotRejectedError
+	switch {
+	case err == nil:
+		return Shared.Delivery{Status: Shared.Delivered}
+	case errors.Is(err, ErrShotQueued):
+		return Shared.Delivery{Status: Shared.Queued, Message: err.Error()}
+	case errors.As(err, &rejected):
+		return Shared.Delivery{Status: Shared.Rejected, Message: fmt.Sprintf("%d %s", rejected.Code, rejected.Message)}
+	case errors.Is(err, ErrShotNotAcknowledged):
+		return Shared.Delivery{Status: Shared.TimedOut, Message: err.Error()}
+	default:
+		return Shared.Delivery{Status: Shared.Failed, Message: err.Error()}
+	}
+}
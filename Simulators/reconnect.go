@@ -0,0 +1,39 @@
+package Simulators
+
+import (
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// minReconnectDelay is the first delay before reconnecting; it doubles up to the configured maximum.
+const minReconnectDelay = time.Second
+
+// MaintainConnection keeps a simulator connected until shutdown is closed. It dials until a connection is made,
+// waiting with exponential backoff between attempts, then serves the connection until it is lost and dials again.
+func MaintainConnection[C any](log *zap.SugaredLogger, name string, maxDelay time.Duration, shutdown <-chan struct{}, dial func() (C, error), serve func(conn C)) {
+	delay := minReconnectDelay
+	for {
+		conn, err := dial()
+		if err != nil {
+			log.Warnf("connecting to %s: %v, retrying in %s", name, err, delay)
+			select {
+			case <-shutdown:
+				return
+			case <-time.After(delay):
+			}
+			delay = min(delay*2, max(maxDelay, minReconnectDelay))
+			continue
+		}
+		delay = minReconnectDelay
+
+		serve(conn)
+
+		select {
+		case <-shutdown:
+			log.Info("shutdown signal received; exiting run loop.")
+			return
+		default:
+		}
+	}
+}
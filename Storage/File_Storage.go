@@ -7,16 +7,61 @@ import (
 	"fmt"
 	"go.uber.org/zap"
 	"os"
+	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 )
 
+// shotFileHeader is the header row of the shot file.
+var shotFileHeader = []string{
+	"Timestamp", "ShotUUID", "ClubType",
+	// Raw Ball Data
+	"BallSpeed", "BallSpinAxis", "BallTotalSpin", "BallBackSpin",
+	"BallSideSpin", "BallHLA", "BallVLA", "BallCarryDistance",
+	// Raw Club Data
+	"ClubSpeed", "ClubSpeedAtImpact", "ClubPath", "ClubAngleOfAttack",
+	"ClubClosureRate", "ClubLie", "ClubLoft", "ClubFaceToTarget",
+	"ClubVerticalFaceImpact", "ClubHorizontalFaceImpact",
+	// Adjusted Ball Data (duplicate columns for modifications)
+	"AdjBallSpeed", "AdjBallSpinAxis", "AdjBallTotalSpin", "AdjBallBackSpin",
+	"AdjBallSideSpin", "AdjBallHLA", "AdjBallVLA", "AdjBallCarryDistance",
+	// Adjusted Club Data (duplicate columns for modifications)
+	"AdjClubSpeed", "AdjClubSpeedAtImpact", "AdjClubPath", "AdjClubAngleOfAttack",
+	"AdjClubClosureRate", "AdjClubLie", "AdjClubLoft", "AdjClubFaceToTarget",
+	"AdjClubVerticalFaceImpact", "AdjClubHorizontalFaceImpact",
+	// Whether the shot reached the simulator
+	"DeliveryStatus", "DeliveryMessage",
+	// Outcome reported by the simulator, empty when it reports none
+	"ResultCarryDistance", "ResultTotalDistance", "ResultApex", "ResultCarryDeviation",
+	"ResultTotalDeviation", "ResultDescentAngle", "ResultFlightTime",
+	// Unit system of the speeds and distances in the row
+	"Units",
+}
+
+// deviceFileHeader is the header row of the device file, which keeps each fused launch monitor's raw shot.
+var deviceFileHeader = []string{
+	"Timestamp", "ShotUUID", "Device", "ClubType",
+	"BallSpeed", "BallSpinAxis", "BallTotalSpin", "BallBackSpin",
+	"BallSideSpin", "BallHLA", "BallVLA", "BallCarryDistance",
+	"ClubSpeed", "ClubSpeedAtImpact", "ClubPath", "ClubAngleOfAttack",
+	"ClubClosureRate", "ClubLie", "ClubLoft", "ClubFaceToTarget",
+	"ClubVerticalFaceImpact", "ClubHorizontalFaceImpact",
+	"Units",
+}
+
 // FileStorage implements the Storage interface for file-based storage.
+// Speeds and distances are written in the display units, which each row records in its Units column.
 type FileStorage struct {
-	file   *os.File
-	writer *csv.Writer
-	mutex  sync.Mutex
-	log    *zap.SugaredLogger
+	file         *os.File
+	writer       *csv.Writer
+	units        Shared.UnitSystem
+	devicePath   string
+	deviceFile   *os.File
+	deviceWriter *csv.Writer
+	deviceUnits  Shared.UnitSystem
+	mutex        sync.Mutex
+	log          *zap.SugaredLogger
 }
 
 // NewFileStorage initializes a new FileStorage instance.
@@ -39,30 +84,45 @@ func NewFileStorage(logger *zap.Logger, config Shared.Config) (*FileStorage, err
 		return nil, err
 	}
 	if fileInfo.Size() == 0 {
-		header := []string{
-			"Timestamp", "ShotUUID", "ClubType",
-			// Raw Ball Data
-			"BallSpeed", "BallSpinAxis", "BallTotalSpin", "BallBackSpin",
-			"BallSideSpin", "BallHLA", "BallVLA", "BallCarryDistance",
-			// Raw Club Data
-			"ClubSpeed", "ClubSpeedAtImpact", "ClubPath", "ClubAngleOfAttack",
-			"ClubClosureRate", "ClubLie", "ClubLoft", "ClubFaceToTarget",
-			"ClubVerticalFaceImpact", "ClubHorizontalFaceImpact",
-			// Adjusted Ball Data (duplicate columns for modifications)
-			"AdjBallSpeed", "AdjBallSpinAxis", "AdjBallTotalSpin", "AdjBallBackSpin",
-			"AdjBallSideSpin", "AdjBallHLA", "AdjBallVLA", "AdjBallCarryDistance",
-			// Adjusted Club Data (duplicate columns for modifications)
-			"AdjClubSpeed", "AdjClubSpeedAtImpact", "AdjClubPath", "AdjClubAngleOfAttack",
-			"AdjClubClosureRate", "AdjClubLie", "AdjClubLoft", "AdjClubFaceToTarget",
-			"AdjClubVerticalFaceImpact", "AdjClubHorizontalFaceImpact",
-		}
-		if err := writer.Write(header); err != nil {
+		if err := writer.Write(shotFileHeader); err != nil {
 			return nil, err
 		}
 		writer.Flush()
 	}
 
-	return &FileStorage{file: file, writer: writer, log: log}, nil
+	s := &FileStorage{file: file, writer: writer, devicePath: DeviceFilePath(config.Bridge.ShotFile), log: log}
+	s.units = s.fileUnits(config.Bridge.ShotFile, config.Display.Units)
+	s.deviceUnits = s.fileUnits(s.devicePath, config.Display.Units)
+	return s, nil
+}
+
+// fileUnits returns the units to write to a shot or device file in.
+// Files written before rows recorded their units have no Units column and are kept in the standard units.
+func (s *FileStorage) fileUnits(path string, units Shared.UnitSystem) Shared.UnitSystem {
+	file, err := os.Open(path)
+	if err != nil {
+		return units
+	}
+	defer file.Close()
+	header, err := csv.NewReader(file).Read()
+	if err != nil {
+		return units
+	}
+	for _, column := range header {
+		if column == "Units" {
+			return units
+		}
+	}
+	if units != Shared.StandardUnits {
+		s.log.Warnf("%s has no Units column, writing it in %s units; use a new shot file for %s units", path, Shared.StandardUnits, units)
+	}
+	return Shared.StandardUnits
+}
+
+// DeviceFilePath returns the path of the device file kept next to a shot file, e.g. shots-devices.csv.
+func DeviceFilePath(shotFile string) string {
+	ext := filepath.Ext(shotFile)
+	return strings.TrimSuffix(shotFile, ext) + "-devices" + ext
 }
 
 // generateUUID generates a random UUID (version 4).
@@ -93,6 +153,9 @@ func (s *FileStorage) SaveShot(
 	adjustedBall Shared.StandardizedBallData,
 	adjustedClub Shared.StandardizedClubData,
 	clubType string,
+	delivery Shared.Delivery,
+	result *Shared.ShotResult,
+	sources []Shared.DeviceShot,
 ) error {
 	s.mutex.Lock()
 	defer s.mutex.Unlock()
@@ -105,6 +168,12 @@ func (s *FileStorage) SaveShot(
 		return err
 	}
 
+	// Convert speeds and distances to the file's units
+	ball = Shared.ConvertBallUnits(ball, Shared.StandardUnits, s.units)
+	club = Shared.ConvertClubUnits(club, Shared.StandardUnits, s.units)
+	adjustedBall = Shared.ConvertBallUnits(adjustedBall, Shared.StandardUnits, s.units)
+	adjustedClub = Shared.ConvertClubUnits(adjustedClub, Shared.StandardUnits, s.units)
+
 	// Raw Ball Data
 	ballSpeed := fmt.Sprintf("%v", ball.Speed)
 	ballSpinAxis := fmt.Sprintf("%v", ball.SpinAxis)
@@ -168,7 +237,24 @@ func (s *FileStorage) SaveShot(
 		adjClubSpeed, adjClubSpeedAtImpact, adjClubPath, adjClubAngleOfAttack,
 		adjClubClosureRate, adjClubLie, adjClubLoft, adjClubFaceToTarget,
 		adjClubVerticalFaceImpact, adjClubHorizontalFaceImpact,
+		// Delivery
+		string(delivery.Status), delivery.Message,
+	}
+
+	// Simulator result
+	if result != nil {
+		converted := Shared.ConvertShotResultUnits(*result, Shared.StandardUnits, s.units)
+		result = &converted
+		row = append(row,
+			fmt.Sprintf("%v", result.CarryDistance), fmt.Sprintf("%v", result.TotalDistance),
+			fmt.Sprintf("%v", result.Apex), fmt.Sprintf("%v", result.CarryDeviation),
+			fmt.Sprintf("%v", result.TotalDeviation), fmt.Sprintf("%v", result.DescentAngle),
+			fmt.Sprintf("%v", result.FlightTime),
+		)
+	} else {
+		row = append(row, "", "", "", "", "", "", "")
 	}
+	row = append(row, string(s.units))
 
 	// Write data to CSV
 	if err := s.writer.Write(row); err != nil {
@@ -176,6 +262,57 @@ func (s *FileStorage) SaveShot(
 	}
 	s.writer.Flush()
 
+	if len(sources) > 0 {
+		if err := s.saveSources(timestamp, shotUUID, sources); err != nil {
+			return fmt.Errorf("saving device shots: %w", err)
+		}
+	}
+
 	s.log.Infof("shot data saved successfully to %s", s.file.Name())
 	return nil
 }
+
+// saveSources writes each device's raw shot to the device file, opening it on first use.
+func (s *FileStorage) saveSources(timestamp time.Time, shotUUID string, sources []Shared.DeviceShot) error {
+	if s.deviceWriter == nil {
+		file, err := os.OpenFile(s.devicePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			return err
+		}
+		fileInfo, err := file.Stat()
+		if err != nil {
+			_ = file.Close()
+			return err
+		}
+		s.deviceFile = file
+		s.deviceWriter = csv.NewWriter(file)
+		if fileInfo.Size() == 0 {
+			if err := s.deviceWriter.Write(deviceFileHeader); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, source := range sources {
+		source.Ball = Shared.ConvertBallUnits(source.Ball, Shared.StandardUnits, s.deviceUnits)
+		source.Club = Shared.ConvertClubUnits(source.Club, Shared.StandardUnits, s.deviceUnits)
+		row := []string{timestamp.Format(time.RFC3339), shotUUID, source.Device, source.ClubType}
+		for _, f := range Shared.BallFields {
+			row = append(row, fmt.Sprintf("%v", *f.Value(&source.Ball)))
+		}
+		for _, column := range deviceFileHeader[len(row) : len(deviceFileHeader)-1] {
+			name := strings.TrimPrefix(column, "Club")
+			for _, f := range Shared.ClubFields {
+				if f.Name == name {
+					row = append(row, fmt.Sprintf("%v", *f.Value(&source.Club)))
+				}
+			}
+		}
+		row = append(row, string(s.deviceUnits))
+		if err := s.deviceWriter.Write(row); err != nil {
+			return err
+		}
+	}
+	s.deviceWriter.Flush()
+	return s.deviceWriter.Error()
+}
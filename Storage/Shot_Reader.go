@@ -0,0 +1,154 @@
+package Storage
+
+import (
+	"Fairway_Bridge/Shared"
+	"encoding/csv"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// ShotRecord is a shot read back from the shot file, in the standard units.
+type ShotRecord struct {
+	Timestamp    time.Time
+	ShotUUID     string
+	ClubType     string
+	Ball         Shared.StandardizedBallData
+	Club         Shared.StandardizedClubData
+	AdjustedBall Shared.StandardizedBallData
+	AdjustedClub Shared.StandardizedClubData
+	Delivery     Shared.Delivery
+	Result       *Shared.ShotResult
+}
+
+// ReadShots reads every shot from a shot file written by FileStorage.SaveShot.
+// Columns are looked up by header name so files written by older versions can still be read;
+// rows without a Units column are in the standard units.
+func ReadShots(path string) ([]ShotRecord, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("reading shot file: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	columns := make(map[string]int, len(rows[0]))
+	for i, name := range rows[0] {
+		columns[name] = i
+	}
+	if _, ok := columns["Timestamp"]; !ok {
+		return nil, fmt.Errorf("shot file %s has no header row", path)
+	}
+
+	var records []ShotRecord
+	for line, row := range rows[1:] {
+		record, err := parseShotRow(row, columns)
+		if err != nil {
+			return nil, fmt.Errorf("parsing shot on line %d: %w", line+2, err)
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// parseShotRow converts a CSV row into a ShotRecord.
+func parseShotRow(row []string, columns map[string]int) (ShotRecord, error) {
+	var parseErr error
+	text := func(name string) string {
+		i, ok := columns[name]
+		if !ok || i >= len(row) {
+			return ""
+		}
+		return row[i]
+	}
+	number := func(name string) float64 {
+		value := text(name)
+		if value == "" {
+			return 0
+		}
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil && parseErr == nil {
+			parseErr = fmt.Errorf("column %s: %w", name, err)
+		}
+		return f
+	}
+	ball := func(prefix string) Shared.StandardizedBallData {
+		return Shared.StandardizedBallData{
+			Speed:         number(prefix + "BallSpeed"),
+			SpinAxis:      number(prefix + "BallSpinAxis"),
+			TotalSpin:     number(prefix + "BallTotalSpin"),
+			BackSpin:      number(prefix + "BallBackSpin"),
+			SideSpin:      number(prefix + "BallSideSpin"),
+			HLA:           number(prefix + "BallHLA"),
+			VLA:           number(prefix + "BallVLA"),
+			CarryDistance: number(prefix + "BallCarryDistance"),
+		}
+	}
+	club := func(prefix string) Shared.StandardizedClubData {
+		return Shared.StandardizedClubData{
+			Speed:                number(prefix + "ClubSpeed"),
+			AngleOfAttack:        number(prefix + "ClubAngleOfAttack"),
+			FaceToTarget:         number(prefix + "ClubFaceToTarget"),
+			Lie:                  number(prefix + "ClubLie"),
+			Loft:                 number(prefix + "ClubLoft"),
+			Path:                 number(prefix + "ClubPath"),
+			SpeedAtImpact:        number(prefix + "ClubSpeedAtImpact"),
+			VerticalFaceImpact:   number(prefix + "ClubVerticalFaceImpact"),
+			HorizontalFaceImpact: number(prefix + "ClubHorizontalFaceImpact"),
+			ClosureRate:          number(prefix + "ClubClosureRate"),
+		}
+	}
+
+	timestamp, err := time.Parse(time.RFC3339, text("Timestamp"))
+	if err != nil {
+		return ShotRecord{}, fmt.Errorf("column Timestamp: %w", err)
+	}
+	record := ShotRecord{
+		Timestamp:    timestamp,
+		ShotUUID:     text("ShotUUID"),
+		ClubType:     text("ClubType"),
+		Ball:         ball(""),
+		Club:         club(""),
+		AdjustedBall: ball("Adj"),
+		AdjustedClub: club("Adj"),
+		Delivery:     Shared.Delivery{Status: Shared.DeliveryStatus(text("DeliveryStatus")), Message: text("DeliveryMessage")},
+	}
+	if text("ResultCarryDistance") != "" {
+		record.Result = &Shared.ShotResult{
+			CarryDistance:  number("ResultCarryDistance"),
+			TotalDistance:  number("ResultTotalDistance"),
+			Apex:           number("ResultApex"),
+			CarryDeviation: number("ResultCarryDeviation"),
+			TotalDeviation: number("ResultTotalDeviation"),
+			DescentAngle:   number("ResultDescentAngle"),
+			FlightTime:     number("ResultFlightTime"),
+		}
+	}
+
+	// Convert the row's speeds and distances back to the standard units
+	units := Shared.StandardUnits
+	if value := text("Units"); value != "" {
+		if units, err = Shared.ParseUnitSystem(value); err != nil {
+			return ShotRecord{}, fmt.Errorf("column Units: %w", err)
+		}
+	}
+	record.Ball = Shared.ConvertBallUnits(record.Ball, units, Shared.StandardUnits)
+	record.Club = Shared.ConvertClubUnits(record.Club, units, Shared.StandardUnits)
+	record.AdjustedBall = Shared.ConvertBallUnits(record.AdjustedBall, units, Shared.StandardUnits)
+	record.AdjustedClub = Shared.ConvertClubUnits(record.AdjustedClub, units, Shared.StandardUnits)
+	if record.Result != nil {
+		result := Shared.ConvertShotResultUnits(*record.Result, units, Shared.StandardUnits)
+		record.Result = &result
+	}
+	return record, parseErr
+}
@@ -4,6 +4,7 @@ import (
 	"Fairway_Bridge/Cameras"
 	"Fairway_Bridge/HTTP"
 	"Fairway_Bridge/Router"
+	"Fairway_Bridge/Rules"
 	"Fairway_Bridge/Shared"
 	"Fairway_Bridge/Storage"
 	"log"
@@ -24,6 +25,7 @@ func main() {
 
 	// Print out the configuration.
 	config.PrintConfig(logger)
+	Shared.SetHandedness(config.Golfer.Handed)
 
 	// Create a file for storing shots.
 	storage, err := Storage.NewFileStorage(logger, config)
@@ -43,18 +45,27 @@ func main() {
 		logger.Sugar().Fatalf("Failed to connect to camera: %v", err)
 	}
 
-	// Create an API Server & Host UI pages.
-	err = HTTP.Serve(logger, config, cam)
+	// Load the shot rules
+	rules, err := Rules.NewEngine(logger, config)
 	if err != nil {
-		logger.Sugar().Fatalf("Failed to create API server: %v", err)
+		logger.Sugar().Fatalf("Failed to load rules: %v", err)
 	}
 
+	// Hold shots that look like misreads until they are released, edited or discarded
+	quarantine := Router.NewQuarantine(logger)
+
 	// Connect to the Simulator & Launch Monitor
-	launchMonitor, simulator, err := Router.LaunchMonitorToSimulator(logger, storage, cam, config)
+	launchMonitor, simulator, err := Router.LaunchMonitorToSimulator(logger, storage, cam, rules, quarantine, config)
 	if err != nil {
 		logger.Sugar().Fatalf("Failed to start the system router: %v", err)
 	}
 
+	// Create an API Server & Host UI pages.
+	err = HTTP.Serve(logger, config, cam, launchMonitor, simulator, rules, quarantine)
+	if err != nil {
+		logger.Sugar().Fatalf("Failed to create API server: %v", err)
+	}
+
 	logger.Sugar().Infof("Servers are running. Press Ctrl+C to exit.")
 
 	// Block main goroutine until an interrupt signal is received.